@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -16,53 +17,69 @@ import (
 
 // Client represents a GitHub client.
 type Client interface {
-	GetLogin() (*User, error)
-	ListUsers() Users
-	GetUser(string) (*User, error)
-	ListMembers(string) Members
-	GetRepo(string) (*Repo, error)
-	UpdateRepo(string, *UpdateRepoParams) (*Repo, error)
-	ListLabels(string) Labels
-	CreateLabel(string, *CreateLabelParams) (*Label, error)
-	UpdateLabel(string, string, *UpdateLabelParams) (*Label, error)
-	ListIssues(string, *ListIssuesParams) Issues
-	GetIssue(string, int) (*Issue, error)
-	AddAssignees(string, int, []string) error
-	ListComments(string, int) Comments
-	ListEvents(string, int) Events
-	ListPullReqs(string, *ListPullReqsParams) PullReqs
-	GetPullReq(string, int) (*PullReq, error)
-	ListPullReqCommits(string, int) Commits
-	GetDiff(string, string) (string, error)
-	GetCompare(string, string, string) (string, error)
-	ListReviews(string, int) Reviews
-	GetReview(string, int, int) (*Review, error)
-	ListReviewComments(string, int) ReviewComments
-	ListProjects(string, *ListProjectsParams) Projects
-	GetProject(int) (*Project, error)
-	CreateProject(string, *CreateProjectParams) (*Project, error)
-	UpdateProject(int, *UpdateProjectParams) (*Project, error)
-	DeleteProject(int) error
-	ListProjectColumns(int) ProjectColumns
-	GetProjectColumn(int) (*ProjectColumn, error)
-	CreateProjectColumn(int, string) (*ProjectColumn, error)
-	UpdateProjectColumn(int, string) (*ProjectColumn, error)
-	ListProjectCards(int) ProjectCards
-	GetProjectCard(int) (*ProjectCard, error)
-	CreateProjectCard(int, *CreateProjectCardParams) (*ProjectCard, error)
-	UpdateProjectCard(int, *UpdateProjectCardParams) (*ProjectCard, error)
-	MoveProjectCard(int, *MoveProjectCardParams) (*ProjectCard, error)
-	ListMilestones(string, *ListMilestonesParams) Milestones
-	GetMilestone(string, int) (*Milestone, error)
-	CreateMilestone(string, *CreateMilestoneParams) (*Milestone, error)
-	UpdateMilestone(string, int, *UpdateMilestoneParams) (*Milestone, error)
-	DeleteMilestone(string, int) error
-	ListHooks(string) Hooks
-	GetHook(string, int) (*Hook, error)
-	CreateHook(string, *CreateHookParams) (*Hook, error)
-	UpdateHook(string, int, *UpdateHookParams) (*Hook, error)
-	Import(string, *Import) (*ImportResult, error)
-	GetImport(string, int) (*ImportResult, error)
+	GetLogin(context.Context) (*User, error)
+	ListUsers(context.Context) Users
+	GetUser(context.Context, string) (*User, error)
+	ListMembers(context.Context, string) Members
+	GetRepo(context.Context, string) (*Repo, error)
+	UpdateRepo(context.Context, string, *UpdateRepoParams) (*Repo, error)
+	ListLabels(context.Context, string) Labels
+	CreateLabel(context.Context, string, *CreateLabelParams) (*Label, error)
+	UpdateLabel(context.Context, string, string, *UpdateLabelParams) (*Label, error)
+	ListIssues(context.Context, string, *ListIssuesParams) Issues
+	GetIssue(context.Context, string, int) (*Issue, error)
+	UpdateIssue(context.Context, string, int, *UpdateIssueParams) (*Issue, error)
+	AddAssignees(context.Context, string, int, []string) (*Issue, error)
+	CloseIssue(context.Context, string, int, string) (*Issue, error)
+	LockIssue(context.Context, string, int, string) error
+	ListPinnedIssues(context.Context, string) ([]int, error)
+	PinIssue(context.Context, string, int) error
+	ListComments(context.Context, string, int) Comments
+	CreateComment(context.Context, string, int, string) (*Comment, error)
+	ListEvents(context.Context, string, int) Events
+	ListTimeline(context.Context, string, int) Events
+	ListPullReqs(context.Context, string, *ListPullReqsParams) PullReqs
+	GetPullReq(context.Context, string, int) (*PullReq, error)
+	ListPullReqCommits(context.Context, string, int) Commits
+	GetDiff(context.Context, string, string) (string, error)
+	GetCompare(context.Context, string, string, string) (string, error)
+	GetComparePatch(context.Context, string, string, string) (string, error)
+	ListReviews(context.Context, string, int) Reviews
+	GetReview(context.Context, string, int, int) (*Review, error)
+	ListReviewComments(context.Context, string, int) ReviewComments
+	ListProjects(context.Context, string, *ListProjectsParams) Projects
+	GetProject(context.Context, int) (*Project, error)
+	CreateProject(context.Context, string, *CreateProjectParams) (*Project, error)
+	UpdateProject(context.Context, int, *UpdateProjectParams) (*Project, error)
+	DeleteProject(context.Context, int) error
+	ListProjectColumns(context.Context, int) ProjectColumns
+	GetProjectColumn(context.Context, int) (*ProjectColumn, error)
+	CreateProjectColumn(context.Context, int, string) (*ProjectColumn, error)
+	UpdateProjectColumn(context.Context, int, string) (*ProjectColumn, error)
+	ListProjectCards(context.Context, int) ProjectCards
+	GetProjectCard(context.Context, int) (*ProjectCard, error)
+	CreateProjectCard(context.Context, int, *CreateProjectCardParams) (*ProjectCard, error)
+	UpdateProjectCard(context.Context, int, *UpdateProjectCardParams) (*ProjectCard, error)
+	MoveProjectCard(context.Context, int, *MoveProjectCardParams) (*ProjectCard, error)
+	ListMilestones(context.Context, string, *ListMilestonesParams) Milestones
+	GetMilestone(context.Context, string, int) (*Milestone, error)
+	CreateMilestone(context.Context, string, *CreateMilestoneParams) (*Milestone, error)
+	UpdateMilestone(context.Context, string, int, *UpdateMilestoneParams) (*Milestone, error)
+	DeleteMilestone(context.Context, string, int) error
+	ListHooks(context.Context, string) Hooks
+	GetHook(context.Context, string, int) (*Hook, error)
+	CreateHook(context.Context, string, *CreateHookParams) (*Hook, error)
+	UpdateHook(context.Context, string, int, *UpdateHookParams) (*Hook, error)
+	GetGitRef(context.Context, string, string) (*GitRef, error)
+	CreateGitRef(context.Context, string, *CreateGitRefParams) (*GitRef, error)
+	UpdateGitRef(context.Context, string, string, *UpdateGitRefParams) (*GitRef, error)
+	CreateGitBlob(context.Context, string, *CreateGitBlobParams) (*GitBlob, error)
+	CreateGitTree(context.Context, string, *CreateGitTreeParams) (*GitTree, error)
+	GetGitCommit(context.Context, string, string) (*GitCommit, error)
+	CreateGitCommit(context.Context, string, *CreateGitCommitParams) (*GitCommit, error)
+	Import(context.Context, string, *Import) (*ImportResult, error)
+	GetImport(context.Context, string, int) (*ImportResult, error)
+	ListImports(context.Context, string, string) ([]*ImportResult, error)
 }
 
 // New creates a new GitHub client.
@@ -79,7 +96,7 @@ func New(token, endpoint, proxy string, opts ...ClientOption) Client {
 		}
 		cli.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
 	}
-	c := &client{token, endpoint, cli, &Logger{}}
+	c := &client{token, endpoint, cli, &Logger{}, NewPacing()}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -96,36 +113,43 @@ func ClientLogger(l *Logger) ClientOption {
 	}
 }
 
+// ClientPacing returns a client option to set the pacing policy.
+func ClientPacing(p *Pacing) ClientOption {
+	return func(c *client) {
+		c.pacing = p
+	}
+}
+
 type client struct {
 	token, endpoint string
 	client          *http.Client
 	logger          *Logger
+	pacing          *Pacing
 }
 
 func (c *client) url(path string) string {
 	return c.endpoint + path
 }
 
-func (c *client) do(method, path string, body interface{}) (*http.Response, error) {
+func (c *client) do(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var retryCnt int
-	duration := time.Minute
 	for {
-		res, retry, err := c.doOnce(method, path, body)
-		if err == nil || !retry || retryCnt >= 7 {
+		res, retry, err := c.doOnce(ctx, method, path, body)
+		if err == nil || !retry {
 			return res, err
 		}
 		retryCnt++
-		if retryCnt > 2 {
-			duration *= 2
-			if duration > 10*time.Minute {
-				duration = 10 * time.Minute
-			}
+		duration, ok := c.pacing.retryInterval(retryCnt)
+		if !ok {
+			return res, err
+		}
+		if err := sleep(ctx, duration); err != nil {
+			return nil, err
 		}
-		time.Sleep(duration)
 	}
 }
 
-func (c *client) doOnce(method, path string, body interface{}) (*http.Response, bool, error) {
+func (c *client) doOnce(ctx context.Context, method, path string, body interface{}) (*http.Response, bool, error) {
 	var b io.Reader
 	if body != nil {
 		bs, err := json.Marshal(body)
@@ -134,15 +158,15 @@ func (c *client) doOnce(method, path string, body interface{}) (*http.Response,
 		}
 		b = bytes.NewReader(bs)
 	}
-	req, err := c.request(method, path, b)
+	req, err := c.request(ctx, method, path, b)
 	if err != nil {
 		return nil, false, err
 	}
 	return c.doReq(req)
 }
 
-func (c *client) request(method, path string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, path, body)
+func (c *client) request(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -153,19 +177,23 @@ func (c *client) request(method, path string, body io.Reader) (*http.Request, er
 	req.Header.Add("Accept", "application/vnd.github.sailor-v-preview+json")
 	req.Header.Add("Accept", "application/vnd.github.starfox-preview+json")
 	req.Header.Add("Accept", "application/vnd.github.inertia-preview+json")
+	req.Header.Add("Accept", "application/vnd.github.elektra-preview+json")
+	req.Header.Add("Accept", "application/vnd.github.mockingbird-preview+json")
 	req.Header.Add("User-Agent", "github-migrator")
 	return req, nil
 }
 
 func (c *client) doReq(req *http.Request) (*http.Response, bool, error) {
 	c.logger.preRequest(req)
+	start := time.Now()
 	res, err := c.client.Do(req)
 	c.logger.postRequest(res, err)
 	if err != nil {
 		return nil, true, err
 	}
+	limited := c.pacing.observe(res, time.Since(start))
 	if res.StatusCode < 200 || 400 <= res.StatusCode {
-		return nil, 500 <= res.StatusCode, getError(res)
+		return nil, limited || 500 <= res.StatusCode, getError(res)
 	}
 	return res, false, nil
 }
@@ -174,7 +202,7 @@ func getError(res *http.Response) error {
 	defer res.Body.Close()
 	var r struct {
 		Message string    `json:"message"`
-		Errors  apiErrors `json:"errors"`
+		Errors  APIErrors `json:"errors"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
 		return err
@@ -185,8 +213,8 @@ func getError(res *http.Response) error {
 	return fmt.Errorf("%s: %w", r.Message, r.Errors)
 }
 
-func (c *client) get(path string, v interface{}) error {
-	res, err := c.do("GET", path, nil)
+func (c *client) get(ctx context.Context, path string, v interface{}) error {
+	res, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
 		return err
 	}
@@ -197,8 +225,8 @@ func (c *client) get(path string, v interface{}) error {
 	return nil
 }
 
-func (c *client) post(path string, body, v interface{}) error {
-	res, err := c.do("POST", path, body)
+func (c *client) post(ctx context.Context, path string, body, v interface{}) error {
+	res, err := c.do(ctx, "POST", path, body)
 	if err != nil {
 		return err
 	}
@@ -209,8 +237,8 @@ func (c *client) post(path string, body, v interface{}) error {
 	return nil
 }
 
-func (c *client) patch(path string, body, v interface{}) error {
-	res, err := c.do("PATCH", path, body)
+func (c *client) patch(ctx context.Context, path string, body, v interface{}) error {
+	res, err := c.do(ctx, "PATCH", path, body)
 	if err != nil {
 		return err
 	}
@@ -221,8 +249,8 @@ func (c *client) patch(path string, body, v interface{}) error {
 	return nil
 }
 
-func (c *client) delete(path string) error {
-	res, err := c.do("DELETE", path, nil)
+func (c *client) put(ctx context.Context, path string, body interface{}) error {
+	res, err := c.do(ctx, "PUT", path, body)
 	if err != nil {
 		return err
 	}
@@ -230,8 +258,17 @@ func (c *client) delete(path string) error {
 	return nil
 }
 
-func (c *client) getList(path string, v interface{}) (string, error) {
-	res, err := c.do("GET", path, nil)
+func (c *client) delete(ctx context.Context, path string) error {
+	res, err := c.do(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return nil
+}
+
+func (c *client) getList(ctx context.Context, path string, v interface{}) (string, error) {
+	res, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
 		return "", err
 	}
@@ -242,6 +279,30 @@ func (c *client) getList(path string, v interface{}) (string, error) {
 	return getNext(res.Header), nil
 }
 
+// send sends the value to the channel of a list, or gives up when the context
+// is done (the consumer may have stopped receiving).
+func send(ctx context.Context, ch chan<- interface{}, x interface{}) bool {
+	select {
+	case ch <- x:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleep pauses for the duration, or returns the error of the context when it
+// is done in the meantime.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func getNext(header http.Header) string {
 	xs := header["Link"]
 	if len(xs) == 0 {
@@ -1,7 +1,83 @@
 package github
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 func TestNew(t *testing.T) {
 	var _ Client = New("token", "http://localhost", "")
 }
+
+func TestClientListCanceled(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/example/test/issues?page=2>; rel="next"`, ts.URL))
+		fmt.Fprint(w, `[{"number":1},{"number":2}]`)
+	}))
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	is := New("token", ts.URL, "").ListIssues(ctx, "example/test", &ListIssuesParams{})
+	i, err := is.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, i.Number)
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range is {
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the list producer did not stop on canceled")
+	}
+}
+
+func TestClientPinIssue(t *testing.T) {
+	var queries []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/graphql", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body["variables"].(map[string]interface{}))
+		if len(queries) == 1 {
+			fmt.Fprint(w, `{"data":{"repository":{"issue":{"id":"I_1"}}}}`)
+		} else {
+			fmt.Fprint(w, `{"data":null,"errors":[{"message":"Issue cannot be pinned"}]}`)
+		}
+	}))
+	defer ts.Close()
+	err := New("token", ts.URL+"/api/v3", "").PinIssue(context.Background(), "example/test", 1)
+	require.Error(t, err)
+	assert.Equal(t, "PinIssue example/test/issues/1: Issue cannot be pinned", err.Error())
+	assert.Equal(t, []map[string]interface{}{
+		{"owner": "example", "name": "test", "number": float64(1)},
+		{"id": "I_1"},
+	}, queries)
+}
+
+func TestClientGetCompare(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/example/test/compare/base...head", r.URL.Path)
+		fmt.Fprint(w, strings.Repeat("+", 2*maxDiffSize))
+	}))
+	defer ts.Close()
+	cli := New("token", ts.URL, "")
+	diff, err := cli.GetCompare(context.Background(), "example/test", "base", "head")
+	require.NoError(t, err)
+	assert.Len(t, diff, maxDiffSize)
+	diff, err = cli.GetCompare(WithMaxDiffSize(context.Background(), 4*maxDiffSize), "example/test", "base", "head")
+	require.NoError(t, err)
+	assert.Len(t, diff, 2*maxDiffSize)
+}
@@ -1,12 +1,14 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
 
 // Comment represents a comment.
 type Comment struct {
+	ID        int    `json:"id,omitempty"`
 	Body      string `json:"body"`
 	HTMLURL   string `json:"html_url"`
 	User      *User  `json:"user"`
@@ -59,20 +61,22 @@ func CommentsToSlice(cs Comments) ([]*Comment, error) {
 }
 
 // ListComments lists the comments of an issue.
-func (c *client) ListComments(repo string, issueNumber int) Comments {
+func (c *client) ListComments(ctx context.Context, repo string, issueNumber int) Comments {
 	cs := make(chan interface{})
 	go func() {
 		defer close(cs)
 		path := c.url(fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=100", repo, issueNumber))
 		for {
 			var xs []*Comment
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				cs <- fmt.Errorf("ListComments %s/issues/%d: %w", repo, issueNumber, err)
+				send(ctx, cs, fmt.Errorf("ListComments %s/issues/%d: %w", repo, issueNumber, err))
 				break
 			}
 			for _, x := range xs {
-				cs <- x
+				if !send(ctx, cs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -82,3 +86,13 @@ func (c *client) ListComments(repo string, issueNumber int) Comments {
 	}()
 	return Comments(cs)
 }
+
+// CreateComment creates a comment of an issue.
+func (c *client) CreateComment(ctx context.Context, repo string, issueNumber int, body string) (*Comment, error) {
+	var r Comment
+	params := map[string]string{"body": body}
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d/comments", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateComment %s: %w", fmt.Sprintf("%s/issues/%d/comments", repo, issueNumber), err)
+	}
+	return &r, nil
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -74,20 +75,22 @@ func CommitsToSlice(cs Commits) ([]*Commit, error) {
 }
 
 // ListPullReqCommits lists the commits of a pull request.
-func (c *client) ListPullReqCommits(repo string, pullNumber int) Commits {
+func (c *client) ListPullReqCommits(ctx context.Context, repo string, pullNumber int) Commits {
 	cs := make(chan interface{})
 	go func() {
 		defer close(cs)
 		path := c.url(fmt.Sprintf("/repos/%s/pulls/%d/commits?per_page=100", repo, pullNumber))
 		for {
 			var xs []*Commit
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				cs <- fmt.Errorf("ListPullReqCommits %s/pull/%d: %w", repo, pullNumber, err)
+				send(ctx, cs, fmt.Errorf("ListPullReqCommits %s/pull/%d: %w", repo, pullNumber, err))
 				break
 			}
 			for _, x := range xs {
-				cs <- x
+				if !send(ctx, cs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -8,27 +9,43 @@ import (
 
 const maxDiffSize = 1 * 1024 * 1024
 
-func (c *client) GetDiff(repo string, sha string) (string, error) {
-	return c.getDiff("GetDiff", fmt.Sprintf("/repos/%s/commits/%s", repo, sha))
+type maxDiffSizeKey struct{}
+
+// WithMaxDiffSize returns a context to read the diffs up to the size, instead
+// of 1 MiB (for example, to archive the full diffs).
+func WithMaxDiffSize(ctx context.Context, size int64) context.Context {
+	return context.WithValue(ctx, maxDiffSizeKey{}, size)
+}
+
+func (c *client) GetDiff(ctx context.Context, repo string, sha string) (string, error) {
+	return c.getDiff(ctx, "GetDiff", fmt.Sprintf("/repos/%s/commits/%s", repo, sha), "diff")
 }
 
-func (c *client) GetCompare(repo string, base, head string) (string, error) {
-	return c.getDiff("GetCompare", fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head))
+func (c *client) GetCompare(ctx context.Context, repo string, base, head string) (string, error) {
+	return c.getDiff(ctx, "GetCompare", fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head), "diff")
 }
 
-func (c *client) getDiff(name, path string) (string, error) {
-	req, err := c.request("GET", c.url(path), nil)
+func (c *client) GetComparePatch(ctx context.Context, repo string, base, head string) (string, error) {
+	return c.getDiff(ctx, "GetComparePatch", fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head), "patch")
+}
+
+func (c *client) getDiff(ctx context.Context, name, path, format string) (string, error) {
+	req, err := c.request(ctx, "GET", c.url(path), nil)
 	if err != nil {
 		return "", err
 	}
-	req.Header.Add("Accept", "application/vnd.github.v3.diff")
+	req.Header.Add("Accept", "application/vnd.github.v3."+format)
 	res, _, err := c.doReq(req)
 	if err != nil {
 		return "", fmt.Errorf("%s %s: %w", name, strings.TrimPrefix(path, "/repos/"), err)
 	}
 	defer res.Body.Close()
 
-	bs, err := io.ReadAll(&io.LimitedReader{R: res.Body, N: maxDiffSize})
+	size := int64(maxDiffSize)
+	if n, ok := ctx.Value(maxDiffSizeKey{}).(int64); ok {
+		size = n
+	}
+	bs, err := io.ReadAll(&io.LimitedReader{R: res.Body, N: size})
 	if err != nil {
 		return "", err
 	}
@@ -2,23 +2,27 @@ package github
 
 import "fmt"
 
-type apiError struct {
+// APIError represents an error of the API, which is used to describe the
+// validation failures.
+type APIError struct {
 	Resource string `json:"resource"`
 	Code     string `json:"code"`
 	Field    string `json:"field"`
 	Value    string `json:"value"`
+	Location string `json:"location,omitempty"`
 }
 
-func (e *apiError) Error() string {
+func (e *APIError) Error() string {
 	if e.Value == "" {
 		return fmt.Sprintf("%s (%s.%s)", e.Code, e.Resource, e.Field)
 	}
 	return fmt.Sprintf("%s (%s.%s = %q)", e.Code, e.Resource, e.Field, e.Value)
 }
 
-type apiErrors []apiError
+// APIErrors represents a collection of APIError.
+type APIErrors []APIError
 
-func (es apiErrors) Error() string {
+func (es APIErrors) Error() string {
 	var s string
 	for i, e := range es {
 		if i > 0 {
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -12,6 +13,7 @@ type Event struct {
 	Event           string                `json:"event"`
 	Label           *EventLabel           `json:"label"`
 	CommitID        string                `json:"commit_id"`
+	CommitURL       string                `json:"commit_url,omitempty"`
 	Rename          *EventRename          `json:"rename"`
 	LockReason      string                `json:"lock_reason"`
 	Assignee        *User                 `json:"assignee"`
@@ -23,6 +25,7 @@ type Event struct {
 	DismissedReview *EventDismissedReview `json:"dismissed_review"`
 	ProjectCard     *EventProjectCard     `json:"project_card"`
 	Milestone       *EventMilestone       `json:"milestone"`
+	Source          *EventSource          `json:"source,omitempty"`
 	CreatedAt       string                `json:"created_at"`
 }
 
@@ -64,6 +67,12 @@ type EventMilestone struct {
 	Title string `json:"title"`
 }
 
+// EventSource represents the source of a cross-referenced event.
+type EventSource struct {
+	Type  string `json:"type"`
+	Issue *Issue `json:"issue"`
+}
+
 // Events represents a collection of events.
 type Events <-chan interface{}
 
@@ -109,20 +118,50 @@ func EventsToSlice(es Events) ([]*Event, error) {
 }
 
 // ListEvents lists the events of an issue.
-func (c *client) ListEvents(repo string, issueNumber int) Events {
+func (c *client) ListEvents(ctx context.Context, repo string, issueNumber int) Events {
 	es := make(chan interface{})
 	go func() {
 		defer close(es)
 		path := c.url(fmt.Sprintf("/repos/%s/issues/%d/events?per_page=100", repo, issueNumber))
 		for {
 			var xs []*Event
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
+			if err != nil {
+				send(ctx, es, fmt.Errorf("ListEvents %s/issues/%d: %w", repo, issueNumber, err))
+				break
+			}
+			for _, x := range xs {
+				if !send(ctx, es, x) {
+					return
+				}
+			}
+			if next == "" {
+				break
+			}
+			path = next
+		}
+	}()
+	return Events(es)
+}
+
+// ListTimeline lists the timeline events of an issue. The timeline includes
+// the events which are not listed by ListEvents, like cross-referenced.
+func (c *client) ListTimeline(ctx context.Context, repo string, issueNumber int) Events {
+	es := make(chan interface{})
+	go func() {
+		defer close(es)
+		path := c.url(fmt.Sprintf("/repos/%s/issues/%d/timeline?per_page=100", repo, issueNumber))
+		for {
+			var xs []*Event
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				es <- fmt.Errorf("ListEvents %s/issues/%d: %w", repo, issueNumber, err)
+				send(ctx, es, fmt.Errorf("ListTimeline %s/issues/%d: %w", repo, issueNumber, err))
 				break
 			}
 			for _, x := range xs {
-				es <- x
+				if !send(ctx, es, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitRef represents a git reference.
+type GitRef struct {
+	Ref    string `json:"ref"`
+	URL    string `json:"url"`
+	Object struct {
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	} `json:"object"`
+}
+
+// GetGitRef gets the git reference.
+func (c *client) GetGitRef(ctx context.Context, repo string, ref string) (*GitRef, error) {
+	var r GitRef
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/git/ref/%s", repo, ref)), &r); err != nil {
+		return nil, fmt.Errorf("GetGitRef %s: %w", fmt.Sprintf("%s/git/ref/%s", repo, ref), err)
+	}
+	return &r, nil
+}
+
+// CreateGitRefParams represents the parameter for CreateGitRef API.
+type CreateGitRefParams struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// CreateGitRef creates a git reference.
+func (c *client) CreateGitRef(ctx context.Context, repo string, params *CreateGitRefParams) (*GitRef, error) {
+	var r GitRef
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/git/refs", repo)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateGitRef %s: %w", fmt.Sprintf("%s/git/refs", repo), err)
+	}
+	return &r, nil
+}
+
+// UpdateGitRefParams represents the parameter for UpdateGitRef API.
+type UpdateGitRefParams struct {
+	SHA   string `json:"sha"`
+	Force bool   `json:"force"`
+}
+
+// UpdateGitRef updates the git reference.
+func (c *client) UpdateGitRef(ctx context.Context, repo string, ref string, params *UpdateGitRefParams) (*GitRef, error) {
+	var r GitRef
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/git/refs/%s", repo, ref)), params, &r); err != nil {
+		return nil, fmt.Errorf("UpdateGitRef %s: %w", fmt.Sprintf("%s/git/refs/%s", repo, ref), err)
+	}
+	return &r, nil
+}
+
+// GitBlob represents a git blob.
+type GitBlob struct {
+	SHA string `json:"sha"`
+	URL string `json:"url"`
+}
+
+// CreateGitBlobParams represents the parameter for CreateGitBlob API.
+type CreateGitBlobParams struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// CreateGitBlob creates a git blob.
+func (c *client) CreateGitBlob(ctx context.Context, repo string, params *CreateGitBlobParams) (*GitBlob, error) {
+	var r GitBlob
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/git/blobs", repo)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateGitBlob %s: %w", fmt.Sprintf("%s/git/blobs", repo), err)
+	}
+	return &r, nil
+}
+
+// GitTree represents a git tree.
+type GitTree struct {
+	SHA string `json:"sha"`
+	URL string `json:"url"`
+}
+
+// GitTreeEntry represents an entry of a git tree.
+type GitTreeEntry struct {
+	Path    string `json:"path"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	SHA     string `json:"sha,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// CreateGitTreeParams represents the parameter for CreateGitTree API.
+type CreateGitTreeParams struct {
+	BaseTree string          `json:"base_tree,omitempty"`
+	Tree     []*GitTreeEntry `json:"tree"`
+}
+
+// CreateGitTree creates a git tree.
+func (c *client) CreateGitTree(ctx context.Context, repo string, params *CreateGitTreeParams) (*GitTree, error) {
+	var r GitTree
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/git/trees", repo)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateGitTree %s: %w", fmt.Sprintf("%s/git/trees", repo), err)
+	}
+	return &r, nil
+}
+
+// GitCommit represents a git commit.
+type GitCommit struct {
+	SHA     string  `json:"sha"`
+	URL     string  `json:"url"`
+	Message string  `json:"message"`
+	Tree    GitTree `json:"tree"`
+}
+
+// GetGitCommit gets the git commit.
+func (c *client) GetGitCommit(ctx context.Context, repo string, sha string) (*GitCommit, error) {
+	var r GitCommit
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/git/commits/%s", repo, sha)), &r); err != nil {
+		return nil, fmt.Errorf("GetGitCommit %s: %w", fmt.Sprintf("%s/git/commits/%s", repo, sha), err)
+	}
+	return &r, nil
+}
+
+// CreateGitCommitParams represents the parameter for CreateGitCommit API.
+type CreateGitCommitParams struct {
+	Message string   `json:"message"`
+	Tree    string   `json:"tree"`
+	Parents []string `json:"parents"`
+}
+
+// CreateGitCommit creates a git commit.
+func (c *client) CreateGitCommit(ctx context.Context, repo string, params *CreateGitCommitParams) (*GitCommit, error) {
+	var r GitCommit
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/git/commits", repo)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateGitCommit %s: %w", fmt.Sprintf("%s/git/commits", repo), err)
+	}
+	return &r, nil
+}
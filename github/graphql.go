@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// graphqlURL returns the endpoint of the GraphQL API. The endpoint of GitHub
+// Enterprise is /api/graphql while the REST API is /api/v3.
+func (c *client) graphqlURL() string {
+	if strings.HasSuffix(c.endpoint, "/api/v3") {
+		return strings.TrimSuffix(c.endpoint, "/v3") + "/graphql"
+	}
+	return c.endpoint + "/graphql"
+}
+
+func (c *client) graphql(ctx context.Context, query string, variables map[string]interface{}, v interface{}) error {
+	var r struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	body := map[string]interface{}{"query": query, "variables": variables}
+	if err := c.post(ctx, c.graphqlURL(), body, &r); err != nil {
+		return err
+	}
+	if len(r.Errors) > 0 {
+		messages := make([]string, len(r.Errors))
+		for i, e := range r.Errors {
+			messages[i] = e.Message
+		}
+		return errors.New(strings.Join(messages, ", "))
+	}
+	return json.Unmarshal(r.Data, v)
+}
+
+func repoVariables(repo string) map[string]interface{} {
+	i := strings.IndexByte(repo, '/')
+	return map[string]interface{}{"owner": repo[:i], "name": repo[i+1:]}
+}
+
+const listPinnedIssuesQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    pinnedIssues(first: 3) {
+      nodes { issue { number } }
+    }
+  }
+}`
+
+// ListPinnedIssues lists the numbers of the pinned issues (up to three).
+func (c *client) ListPinnedIssues(ctx context.Context, repo string) ([]int, error) {
+	var r struct {
+		Repository struct {
+			PinnedIssues struct {
+				Nodes []struct {
+					Issue struct {
+						Number int `json:"number"`
+					} `json:"issue"`
+				} `json:"nodes"`
+			} `json:"pinnedIssues"`
+		} `json:"repository"`
+	}
+	if err := c.graphql(ctx, listPinnedIssuesQuery, repoVariables(repo), &r); err != nil {
+		return nil, fmt.Errorf("ListPinnedIssues %s: %w", repo, err)
+	}
+	xs := make([]int, len(r.Repository.PinnedIssues.Nodes))
+	for i, n := range r.Repository.PinnedIssues.Nodes {
+		xs[i] = n.Issue.Number
+	}
+	return xs, nil
+}
+
+const getIssueNodeIDQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    issue(number: $number) { id }
+  }
+}`
+
+const pinIssueMutation = `mutation($id: ID!) {
+  pinIssue(input: {issueId: $id}) { issue { number } }
+}`
+
+// PinIssue pins the issue.
+func (c *client) PinIssue(ctx context.Context, repo string, issueNumber int) error {
+	var r struct {
+		Repository struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	variables := repoVariables(repo)
+	variables["number"] = issueNumber
+	if err := c.graphql(ctx, getIssueNodeIDQuery, variables, &r); err != nil {
+		return fmt.Errorf("PinIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
+	}
+	if err := c.graphql(ctx, pinIssueMutation,
+		map[string]interface{}{"id": r.Repository.Issue.ID}, &struct{}{}); err != nil {
+		return fmt.Errorf("PinIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
+	}
+	return nil
+}
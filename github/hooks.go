@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -70,22 +71,24 @@ func HooksToSlice(hs Hooks) ([]*Hook, error) {
 }
 
 // ListHooks lists the hooks.
-func (c *client) ListHooks(repo string) Hooks {
+func (c *client) ListHooks(ctx context.Context, repo string) Hooks {
 	hs := make(chan interface{})
 	go func() {
 		defer close(hs)
 		path := c.url(fmt.Sprintf("/repos/%s/hooks?per_page=100", repo))
 		for {
 			var xs []*Hook
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
 				if err.Error() != "Not Found" {
-					hs <- fmt.Errorf("ListHooks %s: %w", repo, err)
+					send(ctx, hs, fmt.Errorf("ListHooks %s: %w", repo, err))
 				}
 				break
 			}
 			for _, x := range xs {
-				hs <- x
+				if !send(ctx, hs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -97,9 +100,9 @@ func (c *client) ListHooks(repo string) Hooks {
 }
 
 // GetHook gets the hook.
-func (c *client) GetHook(repo string, hookID int) (*Hook, error) {
+func (c *client) GetHook(ctx context.Context, repo string, hookID int) (*Hook, error) {
 	var r Hook
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/hooks/%d", repo, hookID)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/hooks/%d", repo, hookID)), &r); err != nil {
 		return nil, fmt.Errorf("GetHook %s: %w", fmt.Sprintf("%s/hooks/%d", repo, hookID), err)
 	}
 	return &r, nil
@@ -114,10 +117,10 @@ type CreateHookParams struct {
 }
 
 // CreateHook creates a hook.
-func (c *client) CreateHook(repo string, params *CreateHookParams) (*Hook, error) {
+func (c *client) CreateHook(ctx context.Context, repo string, params *CreateHookParams) (*Hook, error) {
 	params.Name = "web"
 	var r Hook
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/hooks", repo)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/hooks", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("CreateHook %s: %w", fmt.Sprintf("%s/hooks", repo), err)
 	}
 	return &r, nil
@@ -131,9 +134,9 @@ type UpdateHookParams struct {
 }
 
 // UpdateHook updates the hook.
-func (c *client) UpdateHook(repo string, hookID int, params *UpdateHookParams) (*Hook, error) {
+func (c *client) UpdateHook(ctx context.Context, repo string, hookID int, params *UpdateHookParams) (*Hook, error) {
 	var r Hook
-	if err := c.patch(c.url(fmt.Sprintf("/repos/%s/hooks/%d", repo, hookID)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/hooks/%d", repo, hookID)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateHook %s: %w", fmt.Sprintf("%s/hooks/%d", repo, hookID), err)
 	}
 	return &r, nil
@@ -1,6 +1,9 @@
 package github
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Import represents an importing object.
 type Import struct {
@@ -32,27 +35,38 @@ type ImportResult struct {
 	ID              int       `json:"id"`
 	Status          string    `json:"status"`
 	URL             string    `json:"url"`
+	IssueURL        string    `json:"issue_url,omitempty"`
 	ImportIssuesURL string    `json:"import_issues_url"`
 	RepositoryURL   string    `json:"repository_url"`
 	CreatedAt       string    `json:"created_at"`
 	UpdatedAt       string    `json:"updated_at"`
-	Errors          apiErrors `json:"errors"`
+	Errors          APIErrors `json:"errors"`
 }
 
 // Import imports an importing object.
-func (c *client) Import(repo string, params *Import) (*ImportResult, error) {
+func (c *client) Import(ctx context.Context, repo string, params *Import) (*ImportResult, error) {
 	var r ImportResult
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/import/issues", repo)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/import/issues", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("Import %s: %w", fmt.Sprintf("%s/import/issues", repo), err)
 	}
 	return &r, nil
 }
 
 // GetImport gets the importing status.
-func (c *client) GetImport(repo string, id int) (*ImportResult, error) {
+func (c *client) GetImport(ctx context.Context, repo string, id int) (*ImportResult, error) {
 	var r ImportResult
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/import/issues/%d", repo, id)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/import/issues/%d", repo, id)), &r); err != nil {
 		return nil, fmt.Errorf("GetImport %s: %w", fmt.Sprintf("%s/import/issues/%d", repo, id), err)
 	}
 	return &r, nil
 }
+
+// ListImports lists the importing statuses created since the time.
+func (c *client) ListImports(ctx context.Context, repo, since string) ([]*ImportResult, error) {
+	var r []*ImportResult
+	path := newPath(fmt.Sprintf("/repos/%s/import/issues", repo)).query("since", since)
+	if err := c.get(ctx, c.url(path.String()), &r); err != nil {
+		return nil, fmt.Errorf("ListImports %s: %w", fmt.Sprintf("%s/import/issues", repo), err)
+	}
+	return r, nil
+}
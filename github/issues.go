@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,9 @@ type Issue struct {
 	Number      int               `json:"number"`
 	Title       string            `json:"title"`
 	State       IssueState        `json:"state"`
+	StateReason string            `json:"state_reason,omitempty"`
+	Locked      bool              `json:"locked,omitempty"`
+	LockReason  string            `json:"active_lock_reason,omitempty"`
 	Body        string            `json:"body"`
 	HTMLURL     string            `json:"html_url"`
 	User        *User             `json:"user"`
@@ -272,20 +276,22 @@ func listIssuesPath(repo string, params *ListIssuesParams) string {
 }
 
 // ListIssues lists the issues.
-func (c *client) ListIssues(repo string, params *ListIssuesParams) Issues {
+func (c *client) ListIssues(ctx context.Context, repo string, params *ListIssuesParams) Issues {
 	is := make(chan interface{})
 	go func() {
 		defer close(is)
 		path := c.url(listIssuesPath(repo, params))
 		for {
 			var xs []*Issue
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				is <- fmt.Errorf("ListIssues %s: %w", repo, err)
+				send(ctx, is, fmt.Errorf("ListIssues %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				is <- x
+				if !send(ctx, is, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -296,19 +302,66 @@ func (c *client) ListIssues(repo string, params *ListIssuesParams) Issues {
 	return Issues(is)
 }
 
-func (c *client) GetIssue(repo string, issueNumber int) (*Issue, error) {
+func (c *client) GetIssue(ctx context.Context, repo string, issueNumber int) (*Issue, error) {
 	var r Issue
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/issues/%d", repo, issueNumber)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d", repo, issueNumber)), &r); err != nil {
 		return nil, fmt.Errorf("GetIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
 	}
 	return &r, nil
 }
 
-func (c *client) AddAssignees(repo string, issueNumber int, assignees []string) error {
+func (c *client) AddAssignees(ctx context.Context, repo string, issueNumber int, assignees []string) (*Issue, error) {
 	var r Issue
 	params := map[string][]string{"assignees": assignees}
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/issues/%d/assignees", repo, issueNumber)), params, &r); err != nil {
-		return fmt.Errorf("AddAssignees %s: %w", fmt.Sprintf("%s/issues/%d/assignees", repo, issueNumber), err)
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d/assignees", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("AddAssignees %s: %w", fmt.Sprintf("%s/issues/%d/assignees", repo, issueNumber), err)
+	}
+	return &r, nil
+}
+
+// UpdateIssueParams represents the parameter for UpdateIssue API.
+type UpdateIssueParams struct {
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     IssueState `json:"state"`
+	Labels    []string   `json:"labels,omitempty"`
+	Assignees []string   `json:"assignees,omitempty"`
+	Milestone int        `json:"milestone,omitempty"`
+}
+
+// UpdateIssue updates the issue.
+func (c *client) UpdateIssue(ctx context.Context, repo string, issueNumber int, params *UpdateIssueParams) (*Issue, error) {
+	var r Issue
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("UpdateIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
+	}
+	return &r, nil
+}
+
+type closeIssueParams struct {
+	State       IssueState `json:"state"`
+	StateReason string     `json:"state_reason,omitempty"`
+}
+
+// CloseIssue closes the issue with the reason (completed or not_planned).
+func (c *client) CloseIssue(ctx context.Context, repo string, issueNumber int, stateReason string) (*Issue, error) {
+	var r Issue
+	params := &closeIssueParams{State: IssueStateClosed, StateReason: stateReason}
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("CloseIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
+	}
+	return &r, nil
+}
+
+// LockIssue locks the conversation of the issue with the reason (off-topic,
+// too heated, resolved or spam).
+func (c *client) LockIssue(ctx context.Context, repo string, issueNumber int, lockReason string) error {
+	params := map[string]string{}
+	if lockReason != "" {
+		params["lock_reason"] = lockReason
+	}
+	if err := c.put(ctx, c.url(fmt.Sprintf("/repos/%s/issues/%d/lock", repo, issueNumber)), params); err != nil {
+		return fmt.Errorf("LockIssue %s: %w", fmt.Sprintf("%s/issues/%d/lock", repo, issueNumber), err)
 	}
 	return nil
 }
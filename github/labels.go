@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -59,20 +60,22 @@ func LabelsToSlice(ls Labels) ([]*Label, error) {
 }
 
 // ListLabels lists the labels of an issue.
-func (c *client) ListLabels(repo string) Labels {
+func (c *client) ListLabels(ctx context.Context, repo string) Labels {
 	ls := make(chan interface{})
 	go func() {
 		defer close(ls)
 		path := c.url(fmt.Sprintf("/repos/%s/labels?per_page=100", repo))
 		for {
 			var xs []*Label
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ls <- fmt.Errorf("ListLabels %s: %w", repo, err)
+				send(ctx, ls, fmt.Errorf("ListLabels %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				ls <- x
+				if !send(ctx, ls, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -90,9 +93,9 @@ type CreateLabelParams struct {
 	Color       string `json:"color"`
 }
 
-func (c *client) CreateLabel(repo string, params *CreateLabelParams) (*Label, error) {
+func (c *client) CreateLabel(ctx context.Context, repo string, params *CreateLabelParams) (*Label, error) {
 	var r Label
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/labels", repo)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/labels", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("CreateLabel %s: %w", fmt.Sprintf("%s/labels", repo), err)
 	}
 	return &r, nil
@@ -105,9 +108,9 @@ type UpdateLabelParams struct {
 	Color       string `json:"color"`
 }
 
-func (c *client) UpdateLabel(repo, name string, params *UpdateLabelParams) (*Label, error) {
+func (c *client) UpdateLabel(ctx context.Context, repo, name string, params *UpdateLabelParams) (*Label, error) {
 	var r Label
-	if err := c.patch(c.url(fmt.Sprintf("/repos/%s/labels/%s", repo, name)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/labels/%s", repo, name)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateLabel %s: %w", fmt.Sprintf("%s/labels/%s", repo, name), err)
 	}
 	return &r, nil
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -59,22 +60,24 @@ func MembersToSlice(ms Members) ([]*Member, error) {
 }
 
 // ListMembers lists the members of the organization.
-func (c *client) ListMembers(org string) Members {
+func (c *client) ListMembers(ctx context.Context, org string) Members {
 	ms := make(chan interface{})
 	go func() {
 		defer close(ms)
 		path := c.url(fmt.Sprintf("/orgs/%s/members?per_page=100", org))
 		for {
 			var xs []*Member
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
 				if err.Error() != "Not Found" {
-					ms <- fmt.Errorf("ListMembers %s: %w", org, err)
+					send(ctx, ms, fmt.Errorf("ListMembers %s: %w", org, err))
 				}
 				break
 			}
 			for _, x := range xs {
-				ms <- x
+				if !send(ctx, ms, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
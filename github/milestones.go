@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -202,20 +203,22 @@ func listMilestonesPath(repo string, params *ListMilestonesParams) string {
 }
 
 // ListMilestones lists the milestones.
-func (c *client) ListMilestones(repo string, params *ListMilestonesParams) Milestones {
+func (c *client) ListMilestones(ctx context.Context, repo string, params *ListMilestonesParams) Milestones {
 	ms := make(chan interface{})
 	go func() {
 		defer close(ms)
 		path := c.url(listMilestonesPath(repo, params))
 		for {
 			var xs []*Milestone
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ms <- fmt.Errorf("ListMilestones %s: %w", repo, err)
+				send(ctx, ms, fmt.Errorf("ListMilestones %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				ms <- x
+				if !send(ctx, ms, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -226,9 +229,9 @@ func (c *client) ListMilestones(repo string, params *ListMilestonesParams) Miles
 	return Milestones(ms)
 }
 
-func (c *client) GetMilestone(repo string, milestoneNumber int) (*Milestone, error) {
+func (c *client) GetMilestone(ctx context.Context, repo string, milestoneNumber int) (*Milestone, error) {
 	var r Milestone
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber)), &r); err != nil {
 		return nil, fmt.Errorf("GetMilestone %s: %w", fmt.Sprintf("%s/milestones/%d", repo, milestoneNumber), err)
 	}
 	return &r, nil
@@ -243,9 +246,9 @@ type CreateMilestoneParams struct {
 }
 
 // CreateMilestone creates a milestone.
-func (c *client) CreateMilestone(repo string, params *CreateMilestoneParams) (*Milestone, error) {
+func (c *client) CreateMilestone(ctx context.Context, repo string, params *CreateMilestoneParams) (*Milestone, error) {
 	var r Milestone
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/milestones", repo)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/milestones", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("CreateMilestone %s: %w", fmt.Sprintf("%s/milestones", repo), err)
 	}
 	return &r, nil
@@ -255,17 +258,17 @@ func (c *client) CreateMilestone(repo string, params *CreateMilestoneParams) (*M
 type UpdateMilestoneParams CreateMilestoneParams
 
 // UpdateMilestone updates the milestone.
-func (c *client) UpdateMilestone(repo string, milestoneNumber int, params *UpdateMilestoneParams) (*Milestone, error) {
+func (c *client) UpdateMilestone(ctx context.Context, repo string, milestoneNumber int, params *UpdateMilestoneParams) (*Milestone, error) {
 	var r Milestone
-	if err := c.patch(c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateMilestone %s: %w", fmt.Sprintf("%s/milestones/%d", repo, milestoneNumber), err)
 	}
 	return &r, nil
 }
 
 // DeleteMilestone deletes the milestone.
-func (c *client) DeleteMilestone(repo string, milestoneNumber int) error {
-	if err := c.delete(c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber))); err != nil {
+func (c *client) DeleteMilestone(ctx context.Context, repo string, milestoneNumber int) error {
+	if err := c.delete(ctx, c.url(fmt.Sprintf("/repos/%s/milestones/%d", repo, milestoneNumber))); err != nil {
 		return fmt.Errorf("DeleteMilestone %s: %w", fmt.Sprintf("%s/milestones/%d", repo, milestoneNumber), err)
 	}
 	return nil
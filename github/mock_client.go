@@ -1,5 +1,7 @@
 package github
 
+import "context"
+
 // MockClient represents a mock for GitHub client.
 type MockClient struct {
 	getLoginCallback            func() (*User, error)
@@ -13,14 +15,22 @@ type MockClient struct {
 	updateLabelCallback         func(string, string, *UpdateLabelParams) (*Label, error)
 	listIssuesCallback          func(string, *ListIssuesParams) Issues
 	getIssueCallback            func(string, int) (*Issue, error)
-	addAssigneesCallback        func(string, int, []string) error
+	updateIssueCallback         func(string, int, *UpdateIssueParams) (*Issue, error)
+	addAssigneesCallback        func(string, int, []string) (*Issue, error)
+	closeIssueCallback          func(string, int, string) (*Issue, error)
+	lockIssueCallback           func(string, int, string) error
+	listPinnedIssuesCallback    func(string) ([]int, error)
+	pinIssueCallback            func(string, int) error
 	listCommentsCallback        func(string, int) Comments
+	createCommentCallback       func(string, int, string) (*Comment, error)
 	listEventsCallback          func(string, int) Events
+	listTimelineCallback        func(string, int) Events
 	listPullReqsCallback        func(string, *ListPullReqsParams) PullReqs
 	getPullReqCallback          func(string, int) (*PullReq, error)
 	listPullReqCommitsCallback  func(string, int) Commits
 	getDiffCallback             func(string, string) (string, error)
 	getCompareCallback          func(string, string, string) (string, error)
+	getComparePatchCallback     func(string, string, string) (string, error)
 	listReviewsCallback         func(string, int) Reviews
 	getReviewCallback           func(string, int, int) (*Review, error)
 	listReviewCommentsCallback  func(string, int) ReviewComments
@@ -47,8 +57,16 @@ type MockClient struct {
 	getHookCallback             func(string, int) (*Hook, error)
 	createHookCallback          func(string, *CreateHookParams) (*Hook, error)
 	updateHookCallback          func(string, int, *UpdateHookParams) (*Hook, error)
+	getGitRefCallback           func(string, string) (*GitRef, error)
+	createGitRefCallback        func(string, *CreateGitRefParams) (*GitRef, error)
+	updateGitRefCallback        func(string, string, *UpdateGitRefParams) (*GitRef, error)
+	createGitBlobCallback       func(string, *CreateGitBlobParams) (*GitBlob, error)
+	createGitTreeCallback       func(string, *CreateGitTreeParams) (*GitTree, error)
+	getGitCommitCallback        func(string, string) (*GitCommit, error)
+	createGitCommitCallback     func(string, *CreateGitCommitParams) (*GitCommit, error)
 	importCallback              func(string, *Import) (*ImportResult, error)
 	getImportCallback           func(string, int) (*ImportResult, error)
+	listImportsCallback         func(string, string) ([]*ImportResult, error)
 }
 
 // MockClientOption is an option of mock client.
@@ -64,7 +82,7 @@ func NewMockClient(opts ...MockClientOption) *MockClient {
 }
 
 // GetLogin ...
-func (c *MockClient) GetLogin() (*User, error) {
+func (c *MockClient) GetLogin(_ context.Context) (*User, error) {
 	if c.getLoginCallback != nil {
 		return c.getLoginCallback()
 	}
@@ -79,7 +97,7 @@ func MockGetLogin(callback func() (*User, error)) MockClientOption {
 }
 
 // ListUsers ...
-func (c *MockClient) ListUsers() Users {
+func (c *MockClient) ListUsers(_ context.Context) Users {
 	if c.listUsersCallback != nil {
 		return c.listUsersCallback()
 	}
@@ -94,7 +112,7 @@ func MockListUsers(callback func() Users) MockClientOption {
 }
 
 // GetUser ...
-func (c *MockClient) GetUser(name string) (*User, error) {
+func (c *MockClient) GetUser(_ context.Context, name string) (*User, error) {
 	if c.getUserCallback != nil {
 		return c.getUserCallback(name)
 	}
@@ -109,7 +127,7 @@ func MockGetUser(callback func(string) (*User, error)) MockClientOption {
 }
 
 // ListMembers ...
-func (c *MockClient) ListMembers(org string) Members {
+func (c *MockClient) ListMembers(_ context.Context, org string) Members {
 	if c.listMembersCallback != nil {
 		return c.listMembersCallback(org)
 	}
@@ -124,7 +142,7 @@ func MockListMembers(callback func(string) Members) MockClientOption {
 }
 
 // GetRepo ...
-func (c *MockClient) GetRepo(repo string) (*Repo, error) {
+func (c *MockClient) GetRepo(_ context.Context, repo string) (*Repo, error) {
 	if c.getRepoCallback != nil {
 		return c.getRepoCallback(repo)
 	}
@@ -139,7 +157,7 @@ func MockGetRepo(callback func(string) (*Repo, error)) MockClientOption {
 }
 
 // UpdateRepo ...
-func (c *MockClient) UpdateRepo(repo string, params *UpdateRepoParams) (*Repo, error) {
+func (c *MockClient) UpdateRepo(_ context.Context, repo string, params *UpdateRepoParams) (*Repo, error) {
 	if c.updateRepoCallback != nil {
 		return c.updateRepoCallback(repo, params)
 	}
@@ -154,7 +172,7 @@ func MockUpdateRepo(callback func(string, *UpdateRepoParams) (*Repo, error)) Moc
 }
 
 // ListLabels ...
-func (c *MockClient) ListLabels(repo string) Labels {
+func (c *MockClient) ListLabels(_ context.Context, repo string) Labels {
 	if c.listLabelsCallback != nil {
 		return c.listLabelsCallback(repo)
 	}
@@ -169,7 +187,7 @@ func MockListLabels(callback func(string) Labels) MockClientOption {
 }
 
 // CreateLabel ...
-func (c *MockClient) CreateLabel(repo string, params *CreateLabelParams) (*Label, error) {
+func (c *MockClient) CreateLabel(_ context.Context, repo string, params *CreateLabelParams) (*Label, error) {
 	if c.createLabelCallback != nil {
 		return c.createLabelCallback(repo, params)
 	}
@@ -184,7 +202,7 @@ func MockCreateLabel(callback func(string, *CreateLabelParams) (*Label, error))
 }
 
 // UpdateLabel ...
-func (c *MockClient) UpdateLabel(repo, name string, params *UpdateLabelParams) (*Label, error) {
+func (c *MockClient) UpdateLabel(_ context.Context, repo, name string, params *UpdateLabelParams) (*Label, error) {
 	if c.updateLabelCallback != nil {
 		return c.updateLabelCallback(repo, name, params)
 	}
@@ -199,7 +217,7 @@ func MockUpdateLabel(callback func(string, string, *UpdateLabelParams) (*Label,
 }
 
 // ListIssues ...
-func (c *MockClient) ListIssues(repo string, params *ListIssuesParams) Issues {
+func (c *MockClient) ListIssues(_ context.Context, repo string, params *ListIssuesParams) Issues {
 	if c.listIssuesCallback != nil {
 		return c.listIssuesCallback(repo, params)
 	}
@@ -214,7 +232,7 @@ func MockListIssues(callback func(string, *ListIssuesParams) Issues) MockClientO
 }
 
 // GetIssue ...
-func (c *MockClient) GetIssue(repo string, issueNumber int) (*Issue, error) {
+func (c *MockClient) GetIssue(_ context.Context, repo string, issueNumber int) (*Issue, error) {
 	if c.getIssueCallback != nil {
 		return c.getIssueCallback(repo, issueNumber)
 	}
@@ -228,8 +246,23 @@ func MockGetIssue(callback func(string, int) (*Issue, error)) MockClientOption {
 	}
 }
 
+// UpdateIssue ...
+func (c *MockClient) UpdateIssue(_ context.Context, repo string, issueNumber int, params *UpdateIssueParams) (*Issue, error) {
+	if c.updateIssueCallback != nil {
+		return c.updateIssueCallback(repo, issueNumber, params)
+	}
+	panic("MockClient#UpdateIssue")
+}
+
+// MockUpdateIssue ...
+func MockUpdateIssue(callback func(string, int, *UpdateIssueParams) (*Issue, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.updateIssueCallback = callback
+	}
+}
+
 // AddAssignees ...
-func (c *MockClient) AddAssignees(repo string, issueNumber int, assignees []string) error {
+func (c *MockClient) AddAssignees(_ context.Context, repo string, issueNumber int, assignees []string) (*Issue, error) {
 	if c.addAssigneesCallback != nil {
 		return c.addAssigneesCallback(repo, issueNumber, assignees)
 	}
@@ -237,14 +270,74 @@ func (c *MockClient) AddAssignees(repo string, issueNumber int, assignees []stri
 }
 
 // MockAddAssignees ...
-func MockAddAssignees(callback func(string, int, []string) error) MockClientOption {
+func MockAddAssignees(callback func(string, int, []string) (*Issue, error)) MockClientOption {
 	return func(c *MockClient) {
 		c.addAssigneesCallback = callback
 	}
 }
 
+// CloseIssue ...
+func (c *MockClient) CloseIssue(_ context.Context, repo string, issueNumber int, stateReason string) (*Issue, error) {
+	if c.closeIssueCallback != nil {
+		return c.closeIssueCallback(repo, issueNumber, stateReason)
+	}
+	panic("MockClient#CloseIssue")
+}
+
+// MockCloseIssue ...
+func MockCloseIssue(callback func(string, int, string) (*Issue, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.closeIssueCallback = callback
+	}
+}
+
+// LockIssue ...
+func (c *MockClient) LockIssue(_ context.Context, repo string, issueNumber int, lockReason string) error {
+	if c.lockIssueCallback != nil {
+		return c.lockIssueCallback(repo, issueNumber, lockReason)
+	}
+	panic("MockClient#LockIssue")
+}
+
+// MockLockIssue ...
+func MockLockIssue(callback func(string, int, string) error) MockClientOption {
+	return func(c *MockClient) {
+		c.lockIssueCallback = callback
+	}
+}
+
+// ListPinnedIssues ...
+func (c *MockClient) ListPinnedIssues(_ context.Context, repo string) ([]int, error) {
+	if c.listPinnedIssuesCallback != nil {
+		return c.listPinnedIssuesCallback(repo)
+	}
+	panic("MockClient#ListPinnedIssues")
+}
+
+// MockListPinnedIssues ...
+func MockListPinnedIssues(callback func(string) ([]int, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.listPinnedIssuesCallback = callback
+	}
+}
+
+// PinIssue ...
+func (c *MockClient) PinIssue(_ context.Context, repo string, issueNumber int) error {
+	if c.pinIssueCallback != nil {
+		return c.pinIssueCallback(repo, issueNumber)
+	}
+	panic("MockClient#PinIssue")
+}
+
+// MockPinIssue ...
+func MockPinIssue(callback func(string, int) error) MockClientOption {
+	return func(c *MockClient) {
+		c.pinIssueCallback = callback
+	}
+}
+
 // ListComments ...
-func (c *MockClient) ListComments(repo string, issueNumber int) Comments {
+func (c *MockClient) ListComments(_ context.Context, repo string, issueNumber int) Comments {
 	if c.listCommentsCallback != nil {
 		return c.listCommentsCallback(repo, issueNumber)
 	}
@@ -258,8 +351,23 @@ func MockListComments(callback func(string, int) Comments) MockClientOption {
 	}
 }
 
+// CreateComment ...
+func (c *MockClient) CreateComment(_ context.Context, repo string, issueNumber int, body string) (*Comment, error) {
+	if c.createCommentCallback != nil {
+		return c.createCommentCallback(repo, issueNumber, body)
+	}
+	panic("MockClient#CreateComment")
+}
+
+// MockCreateComment ...
+func MockCreateComment(callback func(string, int, string) (*Comment, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createCommentCallback = callback
+	}
+}
+
 // ListEvents ...
-func (c *MockClient) ListEvents(repo string, issueNumber int) Events {
+func (c *MockClient) ListEvents(_ context.Context, repo string, issueNumber int) Events {
 	if c.listEventsCallback != nil {
 		return c.listEventsCallback(repo, issueNumber)
 	}
@@ -273,8 +381,23 @@ func MockListEvents(callback func(string, int) Events) MockClientOption {
 	}
 }
 
+// ListTimeline ...
+func (c *MockClient) ListTimeline(_ context.Context, repo string, issueNumber int) Events {
+	if c.listTimelineCallback != nil {
+		return c.listTimelineCallback(repo, issueNumber)
+	}
+	panic("MockClient#ListTimeline")
+}
+
+// MockListTimeline ...
+func MockListTimeline(callback func(string, int) Events) MockClientOption {
+	return func(c *MockClient) {
+		c.listTimelineCallback = callback
+	}
+}
+
 // ListPullReqs ...
-func (c *MockClient) ListPullReqs(repo string, params *ListPullReqsParams) PullReqs {
+func (c *MockClient) ListPullReqs(_ context.Context, repo string, params *ListPullReqsParams) PullReqs {
 	if c.listPullReqsCallback != nil {
 		return c.listPullReqsCallback(repo, params)
 	}
@@ -289,7 +412,7 @@ func MockListPullReqs(callback func(string, *ListPullReqsParams) PullReqs) MockC
 }
 
 // GetPullReq ...
-func (c *MockClient) GetPullReq(repo string, pullNumber int) (*PullReq, error) {
+func (c *MockClient) GetPullReq(_ context.Context, repo string, pullNumber int) (*PullReq, error) {
 	if c.getPullReqCallback != nil {
 		return c.getPullReqCallback(repo, pullNumber)
 	}
@@ -304,7 +427,7 @@ func MockGetPullReq(callback func(string, int) (*PullReq, error)) MockClientOpti
 }
 
 // ListPullReqCommits ...
-func (c *MockClient) ListPullReqCommits(repo string, pullNumber int) Commits {
+func (c *MockClient) ListPullReqCommits(_ context.Context, repo string, pullNumber int) Commits {
 	if c.listPullReqCommitsCallback != nil {
 		return c.listPullReqCommitsCallback(repo, pullNumber)
 	}
@@ -319,7 +442,7 @@ func MockListPullReqCommits(callback func(string, int) Commits) MockClientOption
 }
 
 // GetDiff ...
-func (c *MockClient) GetDiff(repo string, sha string) (string, error) {
+func (c *MockClient) GetDiff(_ context.Context, repo string, sha string) (string, error) {
 	if c.getDiffCallback != nil {
 		return c.getDiffCallback(repo, sha)
 	}
@@ -334,7 +457,7 @@ func MockGetDiff(callback func(string, string) (string, error)) MockClientOption
 }
 
 // GetCompare ...
-func (c *MockClient) GetCompare(repo string, base, head string) (string, error) {
+func (c *MockClient) GetCompare(_ context.Context, repo string, base, head string) (string, error) {
 	if c.getCompareCallback != nil {
 		return c.getCompareCallback(repo, base, head)
 	}
@@ -348,8 +471,23 @@ func MockGetCompare(callback func(string, string, string) (string, error)) MockC
 	}
 }
 
+// GetComparePatch ...
+func (c *MockClient) GetComparePatch(_ context.Context, repo string, base, head string) (string, error) {
+	if c.getComparePatchCallback != nil {
+		return c.getComparePatchCallback(repo, base, head)
+	}
+	panic("MockClient#GetComparePatch")
+}
+
+// MockGetComparePatch ...
+func MockGetComparePatch(callback func(string, string, string) (string, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.getComparePatchCallback = callback
+	}
+}
+
 // ListReviews ...
-func (c *MockClient) ListReviews(repo string, pullNumber int) Reviews {
+func (c *MockClient) ListReviews(_ context.Context, repo string, pullNumber int) Reviews {
 	if c.listReviewsCallback != nil {
 		return c.listReviewsCallback(repo, pullNumber)
 	}
@@ -364,7 +502,7 @@ func MockListReviews(callback func(string, int) Reviews) MockClientOption {
 }
 
 // GetReview ...
-func (c *MockClient) GetReview(repo string, pullNumber, reviewID int) (*Review, error) {
+func (c *MockClient) GetReview(_ context.Context, repo string, pullNumber, reviewID int) (*Review, error) {
 	if c.getReviewCallback != nil {
 		return c.getReviewCallback(repo, pullNumber, reviewID)
 	}
@@ -379,7 +517,7 @@ func MockGetReview(callback func(string, int, int) (*Review, error)) MockClientO
 }
 
 // ListReviewComments ...
-func (c *MockClient) ListReviewComments(repo string, pullNumber int) ReviewComments {
+func (c *MockClient) ListReviewComments(_ context.Context, repo string, pullNumber int) ReviewComments {
 	if c.listReviewCommentsCallback != nil {
 		return c.listReviewCommentsCallback(repo, pullNumber)
 	}
@@ -394,7 +532,7 @@ func MockListReviewComments(callback func(string, int) ReviewComments) MockClien
 }
 
 // ListProjects ...
-func (c *MockClient) ListProjects(repo string, params *ListProjectsParams) Projects {
+func (c *MockClient) ListProjects(_ context.Context, repo string, params *ListProjectsParams) Projects {
 	if c.listProjectsCallback != nil {
 		return c.listProjectsCallback(repo, params)
 	}
@@ -409,7 +547,7 @@ func MockListProjects(callback func(string, *ListProjectsParams) Projects) MockC
 }
 
 // GetProject ...
-func (c *MockClient) GetProject(projectID int) (*Project, error) {
+func (c *MockClient) GetProject(_ context.Context, projectID int) (*Project, error) {
 	if c.getProjectCallback != nil {
 		return c.getProjectCallback(projectID)
 	}
@@ -424,7 +562,7 @@ func MockGetProject(callback func(int) (*Project, error)) MockClientOption {
 }
 
 // CreateProject ...
-func (c *MockClient) CreateProject(repo string, params *CreateProjectParams) (*Project, error) {
+func (c *MockClient) CreateProject(_ context.Context, repo string, params *CreateProjectParams) (*Project, error) {
 	if c.createProjectCallback != nil {
 		return c.createProjectCallback(repo, params)
 	}
@@ -439,7 +577,7 @@ func MockCreateProject(callback func(string, *CreateProjectParams) (*Project, er
 }
 
 // UpdateProject ...
-func (c *MockClient) UpdateProject(projectID int, params *UpdateProjectParams) (*Project, error) {
+func (c *MockClient) UpdateProject(_ context.Context, projectID int, params *UpdateProjectParams) (*Project, error) {
 	if c.updateProjectCallback != nil {
 		return c.updateProjectCallback(projectID, params)
 	}
@@ -454,7 +592,7 @@ func MockUpdateProject(callback func(int, *UpdateProjectParams) (*Project, error
 }
 
 // DeleteProject ...
-func (c *MockClient) DeleteProject(projectID int) error {
+func (c *MockClient) DeleteProject(_ context.Context, projectID int) error {
 	if c.deleteProjectCallback != nil {
 		return c.deleteProjectCallback(projectID)
 	}
@@ -469,7 +607,7 @@ func MockDeleteProject(callback func(int) error) MockClientOption {
 }
 
 // ListProjectColumns ...
-func (c *MockClient) ListProjectColumns(projectID int) ProjectColumns {
+func (c *MockClient) ListProjectColumns(_ context.Context, projectID int) ProjectColumns {
 	if c.listProjectColumnsCallback != nil {
 		return c.listProjectColumnsCallback(projectID)
 	}
@@ -484,7 +622,7 @@ func MockListProjectColumns(callback func(int) ProjectColumns) MockClientOption
 }
 
 // GetProjectColumn ...
-func (c *MockClient) GetProjectColumn(projectColumnID int) (*ProjectColumn, error) {
+func (c *MockClient) GetProjectColumn(_ context.Context, projectColumnID int) (*ProjectColumn, error) {
 	if c.getProjectColumnCallback != nil {
 		return c.getProjectColumnCallback(projectColumnID)
 	}
@@ -499,7 +637,7 @@ func MockGetProjectColumn(callback func(int) (*ProjectColumn, error)) MockClient
 }
 
 // CreateProjectColumn ...
-func (c *MockClient) CreateProjectColumn(projectID int, name string) (*ProjectColumn, error) {
+func (c *MockClient) CreateProjectColumn(_ context.Context, projectID int, name string) (*ProjectColumn, error) {
 	if c.createProjectColumnCallback != nil {
 		return c.createProjectColumnCallback(projectID, name)
 	}
@@ -514,7 +652,7 @@ func MockCreateProjectColumn(callback func(int, string) (*ProjectColumn, error))
 }
 
 // UpdateProjectColumn ...
-func (c *MockClient) UpdateProjectColumn(projectColumnID int, name string) (*ProjectColumn, error) {
+func (c *MockClient) UpdateProjectColumn(_ context.Context, projectColumnID int, name string) (*ProjectColumn, error) {
 	if c.updateProjectColumnCallback != nil {
 		return c.updateProjectColumnCallback(projectColumnID, name)
 	}
@@ -529,7 +667,7 @@ func MockUpdateProjectColumn(callback func(int, string) (*ProjectColumn, error))
 }
 
 // ListProjectCards ...
-func (c *MockClient) ListProjectCards(columnID int) ProjectCards {
+func (c *MockClient) ListProjectCards(_ context.Context, columnID int) ProjectCards {
 	if c.listProjectCardsCallback != nil {
 		return c.listProjectCardsCallback(columnID)
 	}
@@ -544,7 +682,7 @@ func MockListProjectCards(callback func(int) ProjectCards) MockClientOption {
 }
 
 // GetProjectCard ...
-func (c *MockClient) GetProjectCard(projectCardID int) (*ProjectCard, error) {
+func (c *MockClient) GetProjectCard(_ context.Context, projectCardID int) (*ProjectCard, error) {
 	if c.getProjectCardCallback != nil {
 		return c.getProjectCardCallback(projectCardID)
 	}
@@ -559,7 +697,7 @@ func MockGetProjectCard(callback func(int) (*ProjectCard, error)) MockClientOpti
 }
 
 // CreateProjectCard ...
-func (c *MockClient) CreateProjectCard(columnID int, params *CreateProjectCardParams) (*ProjectCard, error) {
+func (c *MockClient) CreateProjectCard(_ context.Context, columnID int, params *CreateProjectCardParams) (*ProjectCard, error) {
 	if c.createProjectCardCallback != nil {
 		return c.createProjectCardCallback(columnID, params)
 	}
@@ -574,7 +712,7 @@ func MockCreateProjectCard(callback func(int, *CreateProjectCardParams) (*Projec
 }
 
 // UpdateProjectCard ...
-func (c *MockClient) UpdateProjectCard(projectCardID int, params *UpdateProjectCardParams) (*ProjectCard, error) {
+func (c *MockClient) UpdateProjectCard(_ context.Context, projectCardID int, params *UpdateProjectCardParams) (*ProjectCard, error) {
 	if c.updateProjectCardCallback != nil {
 		return c.updateProjectCardCallback(projectCardID, params)
 	}
@@ -589,7 +727,7 @@ func MockUpdateProjectCard(callback func(int, *UpdateProjectCardParams) (*Projec
 }
 
 // MoveProjectCard ...
-func (c *MockClient) MoveProjectCard(projectCardID int, params *MoveProjectCardParams) (*ProjectCard, error) {
+func (c *MockClient) MoveProjectCard(_ context.Context, projectCardID int, params *MoveProjectCardParams) (*ProjectCard, error) {
 	if c.moveProjectCardCallback != nil {
 		return c.moveProjectCardCallback(projectCardID, params)
 	}
@@ -604,7 +742,7 @@ func MockMoveProjectCard(callback func(int, *MoveProjectCardParams) (*ProjectCar
 }
 
 // ListMilestones ...
-func (c *MockClient) ListMilestones(repo string, params *ListMilestonesParams) Milestones {
+func (c *MockClient) ListMilestones(_ context.Context, repo string, params *ListMilestonesParams) Milestones {
 	if c.listMilestonesCallback != nil {
 		return c.listMilestonesCallback(repo, params)
 	}
@@ -619,7 +757,7 @@ func MockListMilestones(callback func(string, *ListMilestonesParams) Milestones)
 }
 
 // GetMilestone ...
-func (c *MockClient) GetMilestone(repo string, milestoneNumber int) (*Milestone, error) {
+func (c *MockClient) GetMilestone(_ context.Context, repo string, milestoneNumber int) (*Milestone, error) {
 	if c.getMilestoneCallback != nil {
 		return c.getMilestoneCallback(repo, milestoneNumber)
 	}
@@ -634,7 +772,7 @@ func MockGetMilestone(callback func(string, int) (*Milestone, error)) MockClient
 }
 
 // CreateMilestone ...
-func (c *MockClient) CreateMilestone(repo string, params *CreateMilestoneParams) (*Milestone, error) {
+func (c *MockClient) CreateMilestone(_ context.Context, repo string, params *CreateMilestoneParams) (*Milestone, error) {
 	if c.createMilestoneCallback != nil {
 		return c.createMilestoneCallback(repo, params)
 	}
@@ -649,7 +787,7 @@ func MockCreateMilestone(callback func(string, *CreateMilestoneParams) (*Milesto
 }
 
 // UpdateMilestone ...
-func (c *MockClient) UpdateMilestone(repo string, milestoneNumber int, params *UpdateMilestoneParams) (*Milestone, error) {
+func (c *MockClient) UpdateMilestone(_ context.Context, repo string, milestoneNumber int, params *UpdateMilestoneParams) (*Milestone, error) {
 	if c.updateMilestoneCallback != nil {
 		return c.updateMilestoneCallback(repo, milestoneNumber, params)
 	}
@@ -664,7 +802,7 @@ func MockUpdateMilestone(callback func(string, int, *UpdateMilestoneParams) (*Mi
 }
 
 // DeleteMilestone ...
-func (c *MockClient) DeleteMilestone(repo string, milestoneNumber int) error {
+func (c *MockClient) DeleteMilestone(_ context.Context, repo string, milestoneNumber int) error {
 	if c.deleteMilestoneCallback != nil {
 		return c.deleteMilestoneCallback(repo, milestoneNumber)
 	}
@@ -679,7 +817,7 @@ func MockDeleteMilestone(callback func(string, int) error) MockClientOption {
 }
 
 // ListHooks ...
-func (c *MockClient) ListHooks(repo string) Hooks {
+func (c *MockClient) ListHooks(_ context.Context, repo string) Hooks {
 	if c.listHooksCallback != nil {
 		return c.listHooksCallback(repo)
 	}
@@ -694,7 +832,7 @@ func MockListHooks(callback func(string) Hooks) MockClientOption {
 }
 
 // GetHook ...
-func (c *MockClient) GetHook(repo string, hookID int) (*Hook, error) {
+func (c *MockClient) GetHook(_ context.Context, repo string, hookID int) (*Hook, error) {
 	if c.getHookCallback != nil {
 		return c.getHookCallback(repo, hookID)
 	}
@@ -709,7 +847,7 @@ func MockGetHook(callback func(string, int) (*Hook, error)) MockClientOption {
 }
 
 // CreateHook ...
-func (c *MockClient) CreateHook(repo string, params *CreateHookParams) (*Hook, error) {
+func (c *MockClient) CreateHook(_ context.Context, repo string, params *CreateHookParams) (*Hook, error) {
 	if c.createHookCallback != nil {
 		return c.createHookCallback(repo, params)
 	}
@@ -724,7 +862,7 @@ func MockCreateHook(callback func(string, *CreateHookParams) (*Hook, error)) Moc
 }
 
 // UpdateHook ...
-func (c *MockClient) UpdateHook(repo string, hookID int, params *UpdateHookParams) (*Hook, error) {
+func (c *MockClient) UpdateHook(_ context.Context, repo string, hookID int, params *UpdateHookParams) (*Hook, error) {
 	if c.updateHookCallback != nil {
 		return c.updateHookCallback(repo, hookID, params)
 	}
@@ -738,8 +876,113 @@ func MockUpdateHook(callback func(string, int, *UpdateHookParams) (*Hook, error)
 	}
 }
 
+// GetGitRef ...
+func (c *MockClient) GetGitRef(_ context.Context, repo string, ref string) (*GitRef, error) {
+	if c.getGitRefCallback != nil {
+		return c.getGitRefCallback(repo, ref)
+	}
+	panic("MockClient#GetGitRef")
+}
+
+// MockGetGitRef ...
+func MockGetGitRef(callback func(string, string) (*GitRef, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.getGitRefCallback = callback
+	}
+}
+
+// CreateGitRef ...
+func (c *MockClient) CreateGitRef(_ context.Context, repo string, params *CreateGitRefParams) (*GitRef, error) {
+	if c.createGitRefCallback != nil {
+		return c.createGitRefCallback(repo, params)
+	}
+	panic("MockClient#CreateGitRef")
+}
+
+// MockCreateGitRef ...
+func MockCreateGitRef(callback func(string, *CreateGitRefParams) (*GitRef, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createGitRefCallback = callback
+	}
+}
+
+// UpdateGitRef ...
+func (c *MockClient) UpdateGitRef(_ context.Context, repo string, ref string, params *UpdateGitRefParams) (*GitRef, error) {
+	if c.updateGitRefCallback != nil {
+		return c.updateGitRefCallback(repo, ref, params)
+	}
+	panic("MockClient#UpdateGitRef")
+}
+
+// MockUpdateGitRef ...
+func MockUpdateGitRef(callback func(string, string, *UpdateGitRefParams) (*GitRef, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.updateGitRefCallback = callback
+	}
+}
+
+// CreateGitBlob ...
+func (c *MockClient) CreateGitBlob(_ context.Context, repo string, params *CreateGitBlobParams) (*GitBlob, error) {
+	if c.createGitBlobCallback != nil {
+		return c.createGitBlobCallback(repo, params)
+	}
+	panic("MockClient#CreateGitBlob")
+}
+
+// MockCreateGitBlob ...
+func MockCreateGitBlob(callback func(string, *CreateGitBlobParams) (*GitBlob, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createGitBlobCallback = callback
+	}
+}
+
+// CreateGitTree ...
+func (c *MockClient) CreateGitTree(_ context.Context, repo string, params *CreateGitTreeParams) (*GitTree, error) {
+	if c.createGitTreeCallback != nil {
+		return c.createGitTreeCallback(repo, params)
+	}
+	panic("MockClient#CreateGitTree")
+}
+
+// MockCreateGitTree ...
+func MockCreateGitTree(callback func(string, *CreateGitTreeParams) (*GitTree, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createGitTreeCallback = callback
+	}
+}
+
+// GetGitCommit ...
+func (c *MockClient) GetGitCommit(_ context.Context, repo string, sha string) (*GitCommit, error) {
+	if c.getGitCommitCallback != nil {
+		return c.getGitCommitCallback(repo, sha)
+	}
+	panic("MockClient#GetGitCommit")
+}
+
+// MockGetGitCommit ...
+func MockGetGitCommit(callback func(string, string) (*GitCommit, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.getGitCommitCallback = callback
+	}
+}
+
+// CreateGitCommit ...
+func (c *MockClient) CreateGitCommit(_ context.Context, repo string, params *CreateGitCommitParams) (*GitCommit, error) {
+	if c.createGitCommitCallback != nil {
+		return c.createGitCommitCallback(repo, params)
+	}
+	panic("MockClient#CreateGitCommit")
+}
+
+// MockCreateGitCommit ...
+func MockCreateGitCommit(callback func(string, *CreateGitCommitParams) (*GitCommit, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createGitCommitCallback = callback
+	}
+}
+
 // Import ...
-func (c *MockClient) Import(repo string, issue *Import) (*ImportResult, error) {
+func (c *MockClient) Import(_ context.Context, repo string, issue *Import) (*ImportResult, error) {
 	if c.importCallback != nil {
 		return c.importCallback(repo, issue)
 	}
@@ -754,7 +997,7 @@ func MockImport(callback func(string, *Import) (*ImportResult, error)) MockClien
 }
 
 // GetImport ...
-func (c *MockClient) GetImport(repo string, id int) (*ImportResult, error) {
+func (c *MockClient) GetImport(_ context.Context, repo string, id int) (*ImportResult, error) {
 	if c.getImportCallback != nil {
 		return c.getImportCallback(repo, id)
 	}
@@ -767,3 +1010,18 @@ func MockGetImport(callback func(string, int) (*ImportResult, error)) MockClient
 		c.getImportCallback = callback
 	}
 }
+
+// ListImports ...
+func (c *MockClient) ListImports(_ context.Context, repo string, since string) ([]*ImportResult, error) {
+	if c.listImportsCallback != nil {
+		return c.listImportsCallback(repo, since)
+	}
+	panic("MockClient#ListImports")
+}
+
+// MockListImports ...
+func MockListImports(callback func(string, string) ([]*ImportResult, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.listImportsCallback = callback
+	}
+}
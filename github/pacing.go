@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pacing represents the policy of the intervals between the requests, polling
+// the statuses of the imports and retrying the failed requests. A Pacing can
+// be shared by the clients and the migrator, so that the adaptive pacing
+// slows down the migration after the clients hit the secondary rate limits.
+type Pacing struct {
+	beforeImport      time.Duration
+	pollImport        time.Duration
+	pollImportMax     time.Duration
+	pollImportRetries int
+	projectColumn     time.Duration
+	projectCard       time.Duration
+	retry             time.Duration
+	retryMax          time.Duration
+	retries           int
+	fastResponse      time.Duration // zero when the adaptive pacing is disabled
+
+	mu         sync.Mutex
+	factor     float64
+	retryAfter time.Duration
+}
+
+// PacingOption is an option of Pacing.
+type PacingOption func(*Pacing)
+
+// NewPacing creates a new Pacing.
+func NewPacing(opts ...PacingOption) *Pacing {
+	p := &Pacing{
+		beforeImport:      500 * time.Millisecond,
+		pollImport:        1 * time.Second,
+		pollImportMax:     10 * time.Second,
+		pollImportRetries: 60,
+		projectColumn:     100 * time.Millisecond,
+		projectCard:       100 * time.Millisecond,
+		retry:             1 * time.Minute,
+		retryMax:          10 * time.Minute,
+		retries:           7,
+		factor:            1,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// PacingBeforeImport returns a pacing option to set the interval before
+// importing each issue.
+func PacingBeforeImport(d time.Duration) PacingOption {
+	return func(p *Pacing) {
+		p.beforeImport = d
+	}
+}
+
+// PacingPollImport returns a pacing option to set the initial and maximum
+// intervals, and the maximum count of polling the status of an import.
+func PacingPollImport(initial, max time.Duration, retries int) PacingOption {
+	return func(p *Pacing) {
+		p.pollImport, p.pollImportMax, p.pollImportRetries = initial, max, retries
+	}
+}
+
+// PacingProjects returns a pacing option to set the intervals after creating
+// each project column and card.
+func PacingProjects(column, card time.Duration) PacingOption {
+	return func(p *Pacing) {
+		p.projectColumn, p.projectCard = column, card
+	}
+}
+
+// PacingRetry returns a pacing option to set the initial and maximum
+// intervals, and the maximum count of retrying the failed requests.
+func PacingRetry(initial, max time.Duration, retries int) PacingOption {
+	return func(p *Pacing) {
+		p.retry, p.retryMax, p.retries = initial, max, retries
+	}
+}
+
+// PacingAdaptive returns a pacing option to enable the adaptive pacing. The
+// intervals are shortened (down to a quarter) while the API responds faster
+// than the duration, and doubled (up to eight times) after each response of
+// the secondary rate limit.
+func PacingAdaptive(fastResponse time.Duration) PacingOption {
+	return func(p *Pacing) {
+		p.fastResponse = fastResponse
+	}
+}
+
+const (
+	minPacingFactor = 0.25
+	maxPacingFactor = 8
+)
+
+// BeforeImport waits before importing an issue.
+func (p *Pacing) BeforeImport(ctx context.Context) error {
+	return p.sleep(ctx, p.beforeImport)
+}
+
+// PollImport waits before polling the status of an import for the retry count.
+// It returns an error when the count reaches the maximum.
+func (p *Pacing) PollImport(ctx context.Context, retry int) error {
+	if retry >= p.pollImportRetries {
+		return errors.New("reached maximum retry count")
+	}
+	return p.sleep(ctx, backoff(p.pollImport, p.pollImportMax, retry))
+}
+
+// AfterProjectColumn waits after creating a project column.
+func (p *Pacing) AfterProjectColumn(ctx context.Context) error {
+	return p.sleep(ctx, p.projectColumn)
+}
+
+// AfterProjectCard waits after creating a project card.
+func (p *Pacing) AfterProjectCard(ctx context.Context) error {
+	return p.sleep(ctx, p.projectCard)
+}
+
+func (p *Pacing) sleep(ctx context.Context, d time.Duration) error {
+	p.mu.Lock()
+	d = time.Duration(float64(d) * p.factor)
+	p.mu.Unlock()
+	return sleep(ctx, d)
+}
+
+// retryInterval returns the interval before retrying a request for the retry
+// count (starting from 1), and false when the count exceeds the maximum.
+func (p *Pacing) retryInterval(retry int) (time.Duration, bool) {
+	if retry > p.retries {
+		return 0, false
+	}
+	d := backoff(p.retry, p.retryMax, retry)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if d < p.retryAfter {
+		d = p.retryAfter
+	}
+	p.retryAfter = 0
+	return d, true
+}
+
+// observe adapts the pacing to the response.
+func (p *Pacing) observe(res *http.Response, elapsed time.Duration) bool {
+	throttled := isRateLimited(res)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if throttled {
+		p.retryAfter = retryAfterOf(res)
+	}
+	if p.fastResponse == 0 {
+		return throttled
+	}
+	switch {
+	case throttled:
+		p.factor *= 2
+	case elapsed < p.fastResponse:
+		p.factor *= 0.9
+	case p.factor < 1:
+		p.factor *= 1.1
+		if p.factor > 1 {
+			p.factor = 1
+		}
+	}
+	if p.factor < minPacingFactor {
+		p.factor = minPacingFactor
+	} else if p.factor > maxPacingFactor {
+		p.factor = maxPacingFactor
+	}
+	return throttled
+}
+
+// backoff doubles the interval after the first two retries.
+func backoff(initial, max time.Duration, retry int) time.Duration {
+	d := initial
+	for i := 2; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// isRateLimited reports whether the response is of the secondary rate limit
+// (or of the primary rate limit, which is reset at the time in the header).
+func isRateLimited(res *http.Response) bool {
+	if res == nil {
+		return false
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return res.Header.Get("Retry-After") != "" ||
+			res.Header.Get("X-RateLimit-Remaining") == "0"
+	}
+	return false
+}
+
+func retryAfterOf(res *http.Response) time.Duration {
+	if s := res.Header.Get("Retry-After"); s != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			return time.Duration(n) * time.Second
+		}
+	}
+	if s := res.Header.Get("X-RateLimit-Reset"); s != "" {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			if d := time.Until(time.Unix(n, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	var ds []time.Duration
+	for i := 0; i < 8; i++ {
+		ds = append(ds, backoff(time.Second, 10*time.Second, i))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Second, time.Second, time.Second, 2 * time.Second,
+		4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second,
+	}, ds)
+}
+
+func TestPacingRetryInterval(t *testing.T) {
+	p := NewPacing(PacingRetry(time.Second, 4*time.Second, 3))
+	d, ok := p.retryInterval(1)
+	assert.Equal(t, time.Second, d)
+	assert.True(t, ok)
+	res := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	res.Header.Set("Retry-After", "30")
+	assert.True(t, p.observe(res, time.Second))
+	d, ok = p.retryInterval(3)
+	assert.Equal(t, 30*time.Second, d)
+	assert.True(t, ok)
+	_, ok = p.retryInterval(4)
+	assert.False(t, ok)
+}
+
+func TestPacingAdaptive(t *testing.T) {
+	p := NewPacing(PacingAdaptive(100 * time.Millisecond))
+	ok := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	for i := 0; i < 100; i++ {
+		assert.False(t, p.observe(ok, 10*time.Millisecond))
+	}
+	assert.Equal(t, minPacingFactor, p.factor)
+	limited := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	for i := 0; i < 3; i++ {
+		assert.True(t, p.observe(limited, 10*time.Millisecond))
+	}
+	assert.Equal(t, 2.0, p.factor)
+	for i := 0; i < 100; i++ {
+		p.observe(ok, time.Second)
+	}
+	assert.Equal(t, 2.0, p.factor)
+	for i := 0; i < 100; i++ {
+		p.observe(ok, 10*time.Millisecond)
+	}
+	assert.Equal(t, minPacingFactor, p.factor)
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -76,20 +77,22 @@ func ProjectCardsToSlice(ps ProjectCards) ([]*ProjectCard, error) {
 }
 
 // ListProjectCards lists the project cards.
-func (c *client) ListProjectCards(columnID int) ProjectCards {
+func (c *client) ListProjectCards(ctx context.Context, columnID int) ProjectCards {
 	ps := make(chan interface{})
 	go func() {
 		defer close(ps)
 		path := c.url(fmt.Sprintf("/projects/columns/%d/cards?per_page=100", columnID))
 		for {
 			var xs []*ProjectCard
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ps <- fmt.Errorf("ListProjectCards %d: %w", columnID, err)
+				send(ctx, ps, fmt.Errorf("ListProjectCards %d: %w", columnID, err))
 				break
 			}
 			for _, x := range xs {
-				ps <- x
+				if !send(ctx, ps, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -100,9 +103,9 @@ func (c *client) ListProjectCards(columnID int) ProjectCards {
 	return ProjectCards(ps)
 }
 
-func (c *client) GetProjectCard(projectCardID int) (*ProjectCard, error) {
+func (c *client) GetProjectCard(ctx context.Context, projectCardID int) (*ProjectCard, error) {
 	var r ProjectCard
-	if err := c.get(c.url(fmt.Sprintf("/projects/columns/cards/%d", projectCardID)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/projects/columns/cards/%d", projectCardID)), &r); err != nil {
 		return nil, fmt.Errorf("GetProjectCard %s: %w", fmt.Sprintf("projects/columns/cards/%d", projectCardID), err)
 	}
 	return &r, nil
@@ -163,9 +166,9 @@ func (t ProjectCardContentType) GoString() string {
 }
 
 // CreateProjectCard creates a project card.
-func (c *client) CreateProjectCard(columnID int, params *CreateProjectCardParams) (*ProjectCard, error) {
+func (c *client) CreateProjectCard(ctx context.Context, columnID int, params *CreateProjectCardParams) (*ProjectCard, error) {
 	var r ProjectCard
-	if err := c.post(c.url(fmt.Sprintf("/projects/columns/%d/cards", columnID)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/projects/columns/%d/cards", columnID)), params, &r); err != nil {
 		return nil, fmt.Errorf("CreateProjectCard %s: %w", fmt.Sprintf("projects/columns/%d/cards", columnID), err)
 	}
 	return &r, nil
@@ -178,9 +181,9 @@ type UpdateProjectCardParams struct {
 }
 
 // UpdateProjectCard updates the project card.
-func (c *client) UpdateProjectCard(projectCardID int, params *UpdateProjectCardParams) (*ProjectCard, error) {
+func (c *client) UpdateProjectCard(ctx context.Context, projectCardID int, params *UpdateProjectCardParams) (*ProjectCard, error) {
 	var r ProjectCard
-	if err := c.patch(c.url(fmt.Sprintf("/projects/columns/cards/%d", projectCardID)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/projects/columns/cards/%d", projectCardID)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateProjectCard %s: %w", fmt.Sprintf("projects/columns/cards/%d", projectCardID), err)
 	}
 	return &r, nil
@@ -193,9 +196,9 @@ type MoveProjectCardParams struct {
 }
 
 // MoveProjectCard moves the project card.
-func (c *client) MoveProjectCard(projectCardID int, params *MoveProjectCardParams) (*ProjectCard, error) {
+func (c *client) MoveProjectCard(ctx context.Context, projectCardID int, params *MoveProjectCardParams) (*ProjectCard, error) {
 	var r ProjectCard
-	if err := c.post(c.url(fmt.Sprintf("/projects/columns/cards/%d/moves", projectCardID)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/projects/columns/cards/%d/moves", projectCardID)), params, &r); err != nil {
 		return nil, fmt.Errorf("MoveProjectCard %s: %w", fmt.Sprintf("projects/columns/cards/%d/moves", projectCardID), err)
 	}
 	return &r, nil
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -59,20 +60,22 @@ func ProjectColumnsToSlice(ps ProjectColumns) ([]*ProjectColumn, error) {
 }
 
 // ListProjectColumns lists the project columns.
-func (c *client) ListProjectColumns(projectID int) ProjectColumns {
+func (c *client) ListProjectColumns(ctx context.Context, projectID int) ProjectColumns {
 	ps := make(chan interface{})
 	go func() {
 		defer close(ps)
 		path := c.url(fmt.Sprintf("/projects/%d/columns?per_page=100", projectID))
 		for {
 			var xs []*ProjectColumn
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ps <- fmt.Errorf("ListProjectColumns %d: %w", projectID, err)
+				send(ctx, ps, fmt.Errorf("ListProjectColumns %d: %w", projectID, err))
 				break
 			}
 			for _, x := range xs {
-				ps <- x
+				if !send(ctx, ps, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -83,27 +86,27 @@ func (c *client) ListProjectColumns(projectID int) ProjectColumns {
 	return ProjectColumns(ps)
 }
 
-func (c *client) GetProjectColumn(projectColumnID int) (*ProjectColumn, error) {
+func (c *client) GetProjectColumn(ctx context.Context, projectColumnID int) (*ProjectColumn, error) {
 	var r ProjectColumn
-	if err := c.get(c.url(fmt.Sprintf("/projects/columns/%d", projectColumnID)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/projects/columns/%d", projectColumnID)), &r); err != nil {
 		return nil, fmt.Errorf("GetProjectColumn %s: %w", fmt.Sprintf("projects/columns/%d", projectColumnID), err)
 	}
 	return &r, nil
 }
 
 // CreateProjectColumn creates a project column.
-func (c *client) CreateProjectColumn(projectID int, name string) (*ProjectColumn, error) {
+func (c *client) CreateProjectColumn(ctx context.Context, projectID int, name string) (*ProjectColumn, error) {
 	var r ProjectColumn
-	if err := c.post(c.url(fmt.Sprintf("/projects/%d/columns", projectID)), map[string]string{"name": name}, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/projects/%d/columns", projectID)), map[string]string{"name": name}, &r); err != nil {
 		return nil, err
 	}
 	return &r, nil
 }
 
 // UpdateProjectColumn updates the project column.
-func (c *client) UpdateProjectColumn(projectColumnID int, name string) (*ProjectColumn, error) {
+func (c *client) UpdateProjectColumn(ctx context.Context, projectColumnID int, name string) (*ProjectColumn, error) {
 	var r ProjectColumn
-	if err := c.patch(c.url(fmt.Sprintf("/projects/columns/%d", projectColumnID)), map[string]string{"name": name}, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/projects/columns/%d", projectColumnID)), map[string]string{"name": name}, &r); err != nil {
 		return nil, fmt.Errorf("UpdateProjectColumn %s: %w", fmt.Sprintf("projects/columns/%d", projectColumnID), err)
 	}
 	return &r, nil
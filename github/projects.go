@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -155,20 +156,22 @@ func listProjectsPath(repo string, params *ListProjectsParams) string {
 }
 
 // ListProjects lists the projects.
-func (c *client) ListProjects(repo string, params *ListProjectsParams) Projects {
+func (c *client) ListProjects(ctx context.Context, repo string, params *ListProjectsParams) Projects {
 	ps := make(chan interface{})
 	go func() {
 		defer close(ps)
 		path := c.url(listProjectsPath(repo, params))
 		for {
 			var xs []*Project
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ps <- fmt.Errorf("ListProjects %s: %w", repo, err)
+				send(ctx, ps, fmt.Errorf("ListProjects %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				ps <- x
+				if !send(ctx, ps, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -179,9 +182,9 @@ func (c *client) ListProjects(repo string, params *ListProjectsParams) Projects
 	return Projects(ps)
 }
 
-func (c *client) GetProject(projectID int) (*Project, error) {
+func (c *client) GetProject(ctx context.Context, projectID int) (*Project, error) {
 	var r Project
-	if err := c.get(c.url(fmt.Sprintf("/projects/%d", projectID)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/projects/%d", projectID)), &r); err != nil {
 		return nil, fmt.Errorf("GetProject %s: %w", fmt.Sprintf("projects/%d", projectID), err)
 	}
 	return &r, nil
@@ -194,9 +197,9 @@ type CreateProjectParams struct {
 }
 
 // CreateProject creates a project.
-func (c *client) CreateProject(repo string, params *CreateProjectParams) (*Project, error) {
+func (c *client) CreateProject(ctx context.Context, repo string, params *CreateProjectParams) (*Project, error) {
 	var r Project
-	if err := c.post(c.url(fmt.Sprintf("/repos/%s/projects", repo)), params, &r); err != nil {
+	if err := c.post(ctx, c.url(fmt.Sprintf("/repos/%s/projects", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("CreateProject %s: %w", fmt.Sprintf("%s/projects", repo), err)
 	}
 	return &r, nil
@@ -210,17 +213,17 @@ type UpdateProjectParams struct {
 }
 
 // UpdateProject updates the project.
-func (c *client) UpdateProject(projectID int, params *UpdateProjectParams) (*Project, error) {
+func (c *client) UpdateProject(ctx context.Context, projectID int, params *UpdateProjectParams) (*Project, error) {
 	var r Project
-	if err := c.patch(c.url(fmt.Sprintf("/projects/%d", projectID)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/projects/%d", projectID)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateProject %s: %w", fmt.Sprintf("projects/%d", projectID), err)
 	}
 	return &r, nil
 }
 
 // DeleteProject deletes the project.
-func (c *client) DeleteProject(projectID int) error {
-	if err := c.delete(c.url(fmt.Sprintf("/projects/%d", projectID))); err != nil {
+func (c *client) DeleteProject(ctx context.Context, projectID int) error {
+	if err := c.delete(ctx, c.url(fmt.Sprintf("/projects/%d", projectID))); err != nil {
 		return fmt.Errorf("DeleteProject %s: %w", fmt.Sprintf("/projects/%d", projectID), err)
 	}
 	return nil
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -166,20 +167,22 @@ func listPullReqsPath(repo string, params *ListPullReqsParams) string {
 }
 
 // ListPullReqs lists the pull requests.
-func (c *client) ListPullReqs(repo string, params *ListPullReqsParams) PullReqs {
+func (c *client) ListPullReqs(ctx context.Context, repo string, params *ListPullReqsParams) PullReqs {
 	ps := make(chan interface{})
 	go func() {
 		defer close(ps)
 		path := c.url(listPullReqsPath(repo, params))
 		for {
 			var xs []*PullReq
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				ps <- fmt.Errorf("ListPullReqs %s: %w", repo, err)
+				send(ctx, ps, fmt.Errorf("ListPullReqs %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				ps <- x
+				if !send(ctx, ps, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -190,9 +193,9 @@ func (c *client) ListPullReqs(repo string, params *ListPullReqsParams) PullReqs
 	return PullReqs(ps)
 }
 
-func (c *client) GetPullReq(repo string, pullNumber int) (*PullReq, error) {
+func (c *client) GetPullReq(ctx context.Context, repo string, pullNumber int) (*PullReq, error) {
 	var r PullReq
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/pulls/%d", repo, pullNumber)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/pulls/%d", repo, pullNumber)), &r); err != nil {
 		return nil, fmt.Errorf("GetPullReq %s: %w", fmt.Sprintf("%s/pulls/%d", repo, pullNumber), err)
 	}
 	return &r, nil
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -14,9 +15,9 @@ type Repo struct {
 	Private     bool   `json:"private"`
 }
 
-func (c *client) GetRepo(repo string) (*Repo, error) {
+func (c *client) GetRepo(ctx context.Context, repo string) (*Repo, error) {
 	var r Repo
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s", repo)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s", repo)), &r); err != nil {
 		return nil, fmt.Errorf("GetRepo %s: %w", repo, err)
 	}
 	return &r, nil
@@ -31,9 +32,9 @@ type UpdateRepoParams struct {
 }
 
 // UpdateRepo updates a repository.
-func (c *client) UpdateRepo(repo string, params *UpdateRepoParams) (*Repo, error) {
+func (c *client) UpdateRepo(ctx context.Context, repo string, params *UpdateRepoParams) (*Repo, error) {
 	var r Repo
-	if err := c.patch(c.url(fmt.Sprintf("/repos/%s", repo)), params, &r); err != nil {
+	if err := c.patch(ctx, c.url(fmt.Sprintf("/repos/%s", repo)), params, &r); err != nil {
 		return nil, fmt.Errorf("UpdateRepo %s: %w", repo, err)
 	}
 	return &r, nil
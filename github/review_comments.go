@@ -1,21 +1,29 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
 
 // ReviewComment represents a review comment.
 type ReviewComment struct {
-	ID          int    `json:"id"`
-	Path        string `json:"path"`
-	Body        string `json:"body"`
-	DiffHunk    string `json:"diff_hunk"`
-	HTMLURL     string `json:"html_url"`
-	User        *User  `json:"user"`
-	InReplyToID int    `json:"in_reply_to_id"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID                  int    `json:"id"`
+	PullRequestReviewID int    `json:"pull_request_review_id,omitempty"`
+	Path                string `json:"path"`
+	Body                string `json:"body"`
+	DiffHunk            string `json:"diff_hunk"`
+	Line                int    `json:"line,omitempty"`
+	StartLine           int    `json:"start_line,omitempty"`
+	Side                string `json:"side,omitempty"`
+	Position            *int   `json:"position,omitempty"`
+	CommitID            string `json:"commit_id"`
+	OriginalCommitID    string `json:"original_commit_id,omitempty"`
+	HTMLURL             string `json:"html_url"`
+	User                *User  `json:"user"`
+	InReplyToID         int    `json:"in_reply_to_id"`
+	CreatedAt           string `json:"created_at"`
+	UpdatedAt           string `json:"updated_at"`
 }
 
 // ReviewComments represents a collection of review comments.
@@ -63,20 +71,22 @@ func ReviewCommentsToSlice(cs ReviewComments) ([]*ReviewComment, error) {
 }
 
 // ListReviewComments lists the review comments of a pull request.
-func (c *client) ListReviewComments(repo string, pullNumber int) ReviewComments {
+func (c *client) ListReviewComments(ctx context.Context, repo string, pullNumber int) ReviewComments {
 	cs := make(chan interface{})
 	go func() {
 		defer close(cs)
 		path := c.url(fmt.Sprintf("/repos/%s/pulls/%d/comments?per_page=100", repo, pullNumber))
 		for {
 			var xs []*ReviewComment
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				cs <- fmt.Errorf("ListReviewComments %s: %w", repo, err)
+				send(ctx, cs, fmt.Errorf("ListReviewComments %s: %w", repo, err))
 				break
 			}
 			for _, x := range xs {
-				cs <- x
+				if !send(ctx, cs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
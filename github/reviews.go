@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -119,20 +120,22 @@ func ReviewsToSlice(rs Reviews) ([]*Review, error) {
 }
 
 // ListReviews lists the reviews.
-func (c *client) ListReviews(repo string, pullNumber int) Reviews {
+func (c *client) ListReviews(ctx context.Context, repo string, pullNumber int) Reviews {
 	rs := make(chan interface{})
 	go func() {
 		defer close(rs)
 		path := c.url(fmt.Sprintf("/repos/%s/pulls/%d/reviews?per_page=100", repo, pullNumber))
 		for {
 			var xs []*Review
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				rs <- fmt.Errorf("ListReviews %s/pull/%d: %w", repo, pullNumber, err)
+				send(ctx, rs, fmt.Errorf("ListReviews %s/pull/%d: %w", repo, pullNumber, err))
 				break
 			}
 			for _, x := range xs {
-				rs <- x
+				if !send(ctx, rs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -144,9 +147,9 @@ func (c *client) ListReviews(repo string, pullNumber int) Reviews {
 }
 
 // GetReview gets the review.
-func (c *client) GetReview(repo string, pullNumber, reviewID int) (*Review, error) {
+func (c *client) GetReview(ctx context.Context, repo string, pullNumber, reviewID int) (*Review, error) {
 	var r Review
-	if err := c.get(c.url(fmt.Sprintf("/repos/%s/pulls/%d/reviews/%d", repo, pullNumber, reviewID)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/repos/%s/pulls/%d/reviews/%d", repo, pullNumber, reviewID)), &r); err != nil {
 		return nil, fmt.Errorf("GetReview %s: %w", fmt.Sprintf("%s/pulls/%d/reviews/%d", repo, pullNumber, reviewID), err)
 	}
 	return &r, nil
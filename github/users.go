@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -12,9 +13,9 @@ type User struct {
 }
 
 // GetLogin ...
-func (c *client) GetLogin() (*User, error) {
+func (c *client) GetLogin(ctx context.Context) (*User, error) {
 	var r User
-	if err := c.get(c.url("/user"), &r); err != nil {
+	if err := c.get(ctx, c.url("/user"), &r); err != nil {
 		return nil, fmt.Errorf("GetLogin %s: %w", "/user", err)
 	}
 	return &r, nil
@@ -65,20 +66,22 @@ func UsersToSlice(cs Users) ([]*User, error) {
 }
 
 // ListUsers lists all the users.
-func (c *client) ListUsers() Users {
+func (c *client) ListUsers(ctx context.Context) Users {
 	cs := make(chan interface{})
 	go func() {
 		defer close(cs)
 		path := c.url("/users?per_page=100")
 		for {
 			var xs []*User
-			next, err := c.getList(path, &xs)
+			next, err := c.getList(ctx, path, &xs)
 			if err != nil {
-				cs <- fmt.Errorf("ListUsers /users: %w", err)
+				send(ctx, cs, fmt.Errorf("ListUsers /users: %w", err))
 				break
 			}
 			for _, x := range xs {
-				cs <- x
+				if !send(ctx, cs, x) {
+					return
+				}
 			}
 			if next == "" {
 				break
@@ -90,9 +93,9 @@ func (c *client) ListUsers() Users {
 }
 
 // GetUser ...
-func (c *client) GetUser(name string) (*User, error) {
+func (c *client) GetUser(ctx context.Context, name string) (*User, error) {
 	var r User
-	if err := c.get(c.url(fmt.Sprintf("/users/%s", name)), &r); err != nil {
+	if err := c.get(ctx, c.url(fmt.Sprintf("/users/%s", name)), &r); err != nil {
 		return nil, fmt.Errorf("GetUser %s: %w", fmt.Sprintf("/user/%s", name), err)
 	}
 	return &r, nil
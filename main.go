@@ -1,10 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/itchyny/github-migrator/github"
 	"github.com/itchyny/github-migrator/migrator"
@@ -13,25 +22,95 @@ import (
 
 const name = "github-migrator"
 
+// version is set on building (see Makefile), and recorded in the imported
+// issues and comments.
+var version = "devel"
+
 func main() {
 	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintf(os.Stderr, "%s: interrupted\n", name)
+			os.Exit(130)
+		}
 		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
 		os.Exit(1)
 	}
 }
 
 func run(args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go waitInterrupt(cancel)
+	if len(args) == 3 && args[0] == "retry-failed" {
+		return retryFailed(ctx, args[1], args[2])
+	}
+	if len(args) >= 3 && args[0] == "merge" {
+		return merge(ctx, args[1:len(args)-1], args[len(args)-1])
+	}
 	if len(args) != 2 {
-		return fmt.Errorf("usage: %s <source> <target>", name)
+		return fmt.Errorf("usage: %s [retry-failed] <source> <target> (or %s merge <source>... <target>)", name, name)
 	}
-	mig, err := createMigrator(args[0], args[1])
+	mapping, writeMapping, err := createURLMapping()
 	if err != nil {
 		return err
 	}
-	return mig.Migrate()
+	mig, fs, err := createMigrator(ctx, args[0], args[1], migrator.RecordURLs(mapping))
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+	// the mapping is written even when the migration is interrupted
+	err = mig.Migrate(ctx)
+	if e := writeMapping(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// waitInterrupt cancels the migration on the first interrupt, and the second
+// one terminates the process immediately.
+func waitInterrupt(cancel func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	<-ch
+	signal.Stop(ch)
+	fmt.Println("[!!] interrupted: waiting for the imports in flight (interrupt again to terminate)")
+	cancel()
 }
 
-func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client, error) {
+func retryFailed(ctx context.Context, sourcePath, targetPath string) error {
+	path := os.Getenv("GITHUB_MIGRATOR_FAILURE_QUEUE")
+	if path == "" {
+		return errors.New("failure queue not found (specify GITHUB_MIGRATOR_FAILURE_QUEUE)")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	failures, err := migrator.ReadFailures(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	mig, fs, err := createMigrator(ctx, sourcePath, targetPath)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+	// the failures of retrying are written to the new queue, which is opened
+	// in the append mode on creating the migrator, so the old failures are
+	// moved out of the file only after the migrator is created successfully
+	if err := os.WriteFile(path+".old", data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Truncate(path, 0); err != nil {
+		return err
+	}
+	return mig.RetryFailed(ctx, failures)
+}
+
+func createGitHubClient(
+	ctx context.Context, tokenEnv, endpointEnv, proxyEnv string, pacing *github.Pacing,
+) (github.Client, error) {
 	token := os.Getenv(tokenEnv)
 	if token == "" {
 		return nil, fmt.Errorf("GitHub token not found (specify %s)", tokenEnv)
@@ -61,8 +140,9 @@ func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client,
 				}),
 			),
 		),
+		github.ClientPacing(pacing),
 	)
-	user, err := cli.GetLogin()
+	user, err := cli.GetLogin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s (or you may want to set %s)", err, endpointEnv)
 	}
@@ -70,31 +150,392 @@ func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client,
 	return cli, nil
 }
 
-func createMigrator(sourcePath, targetPath string) (migrator.Migrator, error) {
+func createMigrator(
+	ctx context.Context, sourcePath, targetPath string, opts ...migrator.Option,
+) (migrator.Migrator, files, error) {
+	sourceCli, targetCli, pacing, err := createGitHubClients(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := repo.New(targetCli, targetPath)
+	if archive := createDiffArchive(target); archive != nil {
+		opts = append(opts, migrator.ArchiveDiffs(archive))
+	}
+	out, fs, err := openOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+	mig, err := newMigrator(repo.New(sourceCli, sourcePath), target, pacing, out, opts...)
+	if err != nil {
+		fs.Close()
+		return nil, nil, err
+	}
+	return mig, fs, nil
+}
+
+func merge(ctx context.Context, sourcePaths []string, targetPath string) error {
+	if os.Getenv("GITHUB_MIGRATOR_SOURCE_GIT_DIR") != "" {
+		return errors.New("GITHUB_MIGRATOR_SOURCE_GIT_DIR cannot be used on merging the repositories")
+	}
+	if os.Getenv("GITHUB_MIGRATOR_ERROR_POLICY") == "placeholder" {
+		return errors.New("placeholder error policy cannot be used on merging the repositories")
+	}
+	mergeOpts, err := createMergeOptions()
+	if err != nil {
+		return err
+	}
+	mapping, writeMapping, err := createURLMapping()
+	if err != nil {
+		return err
+	}
+	sourceCli, targetCli, pacing, err := createGitHubClients(ctx)
+	if err != nil {
+		return err
+	}
+	target := repo.New(targetCli, targetPath)
+	// the diff archive is shared by the migrators to commit to the same branch,
+	// and so are the report and failure queue to write the lines in order
+	archive := createDiffArchive(target)
+	out, fs, err := openOutputs()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+	labelPrefixes := createMapping("GITHUB_MIGRATOR_LABEL_PREFIXES")
+	migrators := make([]migrator.Migrator, len(sourcePaths))
+	for i, sourcePath := range sourcePaths {
+		opts := []migrator.Option{migrator.LabelOrigin(), migrator.RecordURLs(mapping)}
+		if archive != nil {
+			opts = append(opts, migrator.ArchiveDiffs(archive))
+		}
+		if prefix := labelPrefixes[sourcePath]; prefix != "" {
+			opts = append(opts, migrator.LabelPrefix(prefix))
+		}
+		mig, err := newMigrator(repo.New(sourceCli, sourcePath), target, pacing, out, opts...)
+		if err != nil {
+			return err
+		}
+		migrators[i] = mig
+	}
+	err = migrator.NewMerger(migrators, mergeOpts...).Migrate(ctx)
+	if e := writeMapping(); err == nil {
+		err = e
+	}
+	return err
+}
+
+func createGitHubClients(ctx context.Context) (github.Client, github.Client, *github.Pacing, error) {
+	pacing, err := createPacing()
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	sourceCli, err := createGitHubClient(
+		ctx,
 		"GITHUB_MIGRATOR_SOURCE_API_TOKEN",
 		"GITHUB_MIGRATOR_SOURCE_API_ENDPOINT",
 		"GITHUB_MIGRATOR_SOURCE_PROXY_URL",
+		pacing,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	targetCli, err := createGitHubClient(
+		ctx,
 		"GITHUB_MIGRATOR_TARGET_API_TOKEN",
 		"GITHUB_MIGRATOR_TARGET_API_ENDPOINT",
 		"GITHUB_MIGRATOR_TARGET_PROXY_URL",
+		pacing,
 	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return sourceCli, targetCli, pacing, nil
+}
+
+func newMigrator(
+	source, target *repo.Repo, pacing *github.Pacing, out *outputs, extraOpts ...migrator.Option,
+) (migrator.Migrator, error) {
+	opts, err := createMigratorOptions(source, target, out)
 	if err != nil {
 		return nil, err
 	}
-	source := repo.New(sourceCli, sourcePath)
-	target := repo.New(targetCli, targetPath)
-	return migrator.New(source, target, createUserMapping()), nil
+	opts = append(opts, migrator.Pace(pacing), migrator.Version(version))
+	opts = append(opts, extraOpts...)
+	return migrator.New(
+		source, target, createMapping("GITHUB_MIGRATOR_USER_MAPPING"), opts...,
+	), nil
+}
+
+// outputs holds the files of the report and failure queue, which are opened
+// in the append mode once and shared by the migrators.
+type outputs struct {
+	report, failureQueue *os.File
+}
+
+func openOutputs() (out *outputs, fs files, err error) {
+	defer func() {
+		if err != nil {
+			fs.Close()
+		}
+	}()
+	out = &outputs{}
+	if path := os.Getenv("GITHUB_MIGRATOR_REPORT"); path != "" {
+		if out.report, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644); err != nil {
+			return nil, fs, err
+		}
+		fs = append(fs, out.report)
+	}
+	if path := os.Getenv("GITHUB_MIGRATOR_FAILURE_QUEUE"); path != "" {
+		if out.failureQueue, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644); err != nil {
+			return nil, fs, err
+		}
+		fs = append(fs, out.failureQueue)
+	}
+	return out, fs, nil
+}
+
+// createURLMapping creates the mapping of the URLs to export, and returns the
+// function to write the files after the migration.
+func createURLMapping() (*migrator.URLMapping, func() error, error) {
+	mapping := migrator.NewURLMapping()
+	var writers []func() error
+	if path := os.Getenv("GITHUB_MIGRATOR_URL_MAPPING"); path != "" {
+		var write func(io.Writer) error
+		switch ext := filepath.Ext(path); ext {
+		case ".csv":
+			write = mapping.WriteCSV
+		case ".json":
+			write = mapping.WriteJSON
+		default:
+			return nil, nil, fmt.Errorf("unknown URL mapping format: %q (should be .csv or .json)", ext)
+		}
+		writers = append(writers, func() error { return writeFile(path, write) })
+	}
+	if path := os.Getenv("GITHUB_MIGRATOR_REDIRECT_RULES"); path != "" {
+		var write func(io.Writer) error
+		switch format := os.Getenv("GITHUB_MIGRATOR_REDIRECT_FORMAT"); format {
+		case "", "nginx":
+			write = mapping.WriteNginx
+		case "apache":
+			write = mapping.WriteApache
+		default:
+			return nil, nil, fmt.Errorf("unknown redirect format: %q (should be nginx or apache)", format)
+		}
+		writers = append(writers, func() error { return writeFile(path, write) })
+	}
+	if len(writers) == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	return mapping, func() error {
+		for _, write := range writers {
+			if err := write(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
 }
 
-func createUserMapping() map[string]string {
+func createMergeOptions() ([]migrator.MergeOption, error) {
+	var opts []migrator.MergeOption
+	switch order := os.Getenv("GITHUB_MIGRATOR_MERGE_ORDER"); order {
+	case "", "created":
+	case "source":
+		opts = append(opts, migrator.MergeIssueOrder(migrator.MergeOrderSource))
+	default:
+		return nil, fmt.Errorf("unknown merge order: %q (should be created or source)", order)
+	}
+	switch policy := os.Getenv("GITHUB_MIGRATOR_MILESTONE_COLLISION"); policy {
+	case "", "merge":
+	case "rename":
+		opts = append(opts, migrator.MilestoneCollision(migrator.MilestoneCollisionRename))
+	case "abort":
+		opts = append(opts, migrator.MilestoneCollision(migrator.MilestoneCollisionAbort))
+	default:
+		return nil, fmt.Errorf("unknown milestone collision policy: %q (should be merge, rename or abort)", policy)
+	}
+	return opts, nil
+}
+
+func createPacing() (*github.Pacing, error) {
+	var opts []github.PacingOption
+	if d := os.Getenv("GITHUB_MIGRATOR_IMPORT_INTERVAL"); d != "" {
+		duration, err := time.ParseDuration(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import interval: %w", err)
+		}
+		opts = append(opts, github.PacingBeforeImport(duration))
+	}
+	switch pacing := os.Getenv("GITHUB_MIGRATOR_PACING"); pacing {
+	case "", "fixed":
+	case "adaptive":
+		opts = append(opts, github.PacingAdaptive(500*time.Millisecond))
+	default:
+		return nil, fmt.Errorf("unknown pacing: %q (should be fixed or adaptive)", pacing)
+	}
+	return github.NewPacing(opts...), nil
+}
+
+func createMigratorOptions(source, target *repo.Repo, out *outputs) ([]migrator.Option, error) {
+	var opts []migrator.Option
+	if out.report != nil {
+		opts = append(opts, migrator.Report(out.report))
+	}
+	if repoMapping := createMapping("GITHUB_MIGRATOR_REPO_MAPPING"); len(repoMapping) > 0 {
+		opts = append(opts, migrator.RepoMapping(repoMapping))
+	}
+	if os.Getenv("GITHUB_MIGRATOR_SUPPRESS_MENTIONS") != "" {
+		opts = append(opts, migrator.SuppressMentions(
+			splitList(os.Getenv("GITHUB_MIGRATOR_MENTION_ALLOWLIST")),
+		))
+	}
+	if path := os.Getenv("GITHUB_MIGRATOR_COMMIT_MAP"); path != "" {
+		var commitMapping map[string]string
+		if err := readFile(path, func(r io.Reader) (err error) {
+			commitMapping, err = migrator.ReadCommitMapping(r)
+			return
+		}); err != nil {
+			return nil, err
+		}
+		opts = append(opts, migrator.CommitMapping(commitMapping))
+	}
+	if path := os.Getenv("GITHUB_MIGRATOR_REPLACE_RULES"); path != "" {
+		var rules []*migrator.ReplaceRule
+		if err := readFile(path, func(r io.Reader) (err error) {
+			rules, err = migrator.ReadReplaceRules(r)
+			return
+		}); err != nil {
+			return nil, err
+		}
+		opts = append(opts, migrator.ReplaceRules(rules))
+	}
+	if policy := os.Getenv("GITHUB_MIGRATOR_SECRET_POLICY"); policy != "" {
+		var secretPolicy migrator.SecretPolicy
+		switch policy {
+		case "redact":
+			secretPolicy = migrator.SecretPolicyRedact
+		case "block":
+			secretPolicy = migrator.SecretPolicyBlock
+		default:
+			return nil, fmt.Errorf("unknown secret policy: %q (should be redact or block)", policy)
+		}
+		// the findings are audited only through the report
+		if out.report == nil {
+			return nil, errors.New("secret scanning requires the migration report (specify GITHUB_MIGRATOR_REPORT)")
+		}
+		var patterns []*migrator.SecretPattern
+		if path := os.Getenv("GITHUB_MIGRATOR_SECRET_PATTERNS"); path != "" {
+			if err := readFile(path, func(r io.Reader) (err error) {
+				patterns, err = migrator.ReadSecretPatterns(r)
+				return
+			}); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, migrator.ScanSecrets(secretPolicy, patterns))
+	}
+	if policy := os.Getenv("GITHUB_MIGRATOR_ERROR_POLICY"); policy != "" ||
+		os.Getenv("GITHUB_MIGRATOR_FAILURE_QUEUE") != "" {
+		var errorPolicy migrator.ErrorPolicy
+		switch policy {
+		case "", "abort":
+			errorPolicy = migrator.ErrorPolicyAbort
+		case "placeholder":
+			errorPolicy = migrator.ErrorPolicyPlaceholder
+		default:
+			return nil, fmt.Errorf("unknown error policy: %q (should be abort or placeholder)", policy)
+		}
+		var queue io.Writer
+		if out.failureQueue != nil {
+			queue = out.failureQueue
+		}
+		opts = append(opts, migrator.IssueErrorPolicy(errorPolicy, queue))
+	}
+	if os.Getenv("GITHUB_MIGRATOR_OFFSET_NUMBERS") != "" {
+		if os.Getenv("GITHUB_MIGRATOR_ERROR_POLICY") == "placeholder" {
+			return nil, errors.New("placeholder error policy cannot be used with GITHUB_MIGRATOR_OFFSET_NUMBERS")
+		}
+		opts = append(opts, migrator.OffsetNumbers())
+	}
+	if size := os.Getenv("GITHUB_MIGRATOR_IMPORT_PIPELINE"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid import pipeline size: %q", size)
+		}
+		opts = append(opts, migrator.PipelineImports(n))
+	}
+	gitDir := os.Getenv("GITHUB_MIGRATOR_SOURCE_GIT_DIR")
+	var gitFallback bool
+	for _, fallback := range splitList(os.Getenv("GITHUB_MIGRATOR_DIFF_FALLBACK")) {
+		switch fallback {
+		case "commits":
+			opts = append(opts, migrator.FallbackDiffs(migrator.NewCommitDiffProvider(source)))
+		case "git":
+			if gitDir == "" {
+				return nil, fmt.Errorf("git fallback requires GITHUB_MIGRATOR_SOURCE_GIT_DIR")
+			}
+			opts = append(opts, migrator.FallbackDiffs(migrator.NewGitDiffProvider(gitDir)))
+			gitFallback = true
+		case "skip":
+			opts = append(opts, migrator.SkipMissingDiffs())
+		default:
+			return nil, fmt.Errorf("unknown diff fallback: %q (should be commits, git or skip)", fallback)
+		}
+	}
+	if gitDir != "" && !gitFallback {
+		opts = append(opts, migrator.ProvideDiffs(migrator.NewGitDiffProvider(gitDir)))
+	}
+	return opts, nil
+}
+
+func createDiffArchive(target *repo.Repo) migrator.DiffArchive {
+	if branch := os.Getenv("GITHUB_MIGRATOR_DIFF_ARCHIVE_BRANCH"); branch != "" {
+		return migrator.NewBranchDiffArchive(target, branch)
+	} else if dir := os.Getenv("GITHUB_MIGRATOR_DIFF_ARCHIVE_DIR"); dir != "" {
+		return migrator.NewLocalDiffArchive(dir, os.Getenv("GITHUB_MIGRATOR_DIFF_ARCHIVE_URL"))
+	}
+	return nil
+}
+
+type files []*os.File
+
+func (fs files) Close() error {
+	var err error
+	for _, f := range fs {
+		if e := f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func readFile(path string, read func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := read(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func createMapping(env string) map[string]string {
 	m := make(map[string]string)
-	for _, src := range strings.Split(os.Getenv("GITHUB_MIGRATOR_USER_MAPPING"), ",") {
+	for _, src := range strings.Split(os.Getenv(env), ",") {
 		xs := strings.Split(strings.TrimSpace(src), ":")
 		if len(xs) == 2 && len(xs[0]) > 0 && len(xs[1]) > 0 {
 			m[xs[0]] = xs[1]
@@ -102,3 +543,13 @@ func createUserMapping() map[string]string {
 	}
 	return m
 }
+
+func splitList(src string) []string {
+	var xs []string
+	for _, x := range strings.Split(src, ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			xs = append(xs, x)
+		}
+	}
+	return xs
+}
@@ -0,0 +1,84 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// setImportedAssignee records the assignee sent on importing the issue, which
+// can be dropped (or not be a member of the target) even if the source issue
+// has the assignees.
+func (m *migrator) setImportedAssignee(sourceIssue *github.Issue, assignee string) {
+	if m.importedAssignees == nil {
+		m.importedAssignees = make(map[int]string)
+	}
+	m.importedAssignees[sourceIssue.Number] = assignee
+}
+
+// addAssignees adds all the assignees of the source issue to the imported
+// issue, since the import API accepts only one assignee. The users which
+// cannot be assigned are recorded in the report.
+func (m *migrator) addAssignees(ctx context.Context, sourceIssue *github.Issue) error {
+	imported := m.importedAssignees[sourceIssue.Number]
+	delete(m.importedAssignees, sourceIssue.Number)
+	users := sourceIssue.Assignees
+	if len(users) == 0 && sourceIssue.Assignee != nil {
+		users = []*github.User{sourceIssue.Assignee}
+	}
+	var assignees []string
+	for _, u := range users {
+		target := m.targetLogin(u.Login)
+		isMember, err := m.isTargetMember(target)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			if err := m.reportAssigneeDropped(sourceIssue, target, "not a member of the target"); err != nil {
+				return err
+			}
+			continue
+		}
+		// the assignee sent on importing is already assigned
+		if target != imported {
+			assignees = append(assignees, target)
+		}
+	}
+	if len(assignees) == 0 {
+		return nil
+	}
+	fmt.Printf("[>>] adding the assignees: %s (original: %s)\n", plural(len(assignees), "user"), sourceIssue.HTMLURL)
+	// the assignees are added even on the graceful shutdown
+	issue, err := m.target.AddAssignees(withoutCancel(ctx), m.targetIssueNumber(sourceIssue.Number), assignees)
+	if err != nil {
+		for _, assignee := range assignees {
+			if err := m.reportAssigneeDropped(sourceIssue, assignee, err.Error()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	assigned := make(map[string]bool, len(issue.Assignees))
+	for _, u := range issue.Assignees {
+		assigned[u.Login] = true
+	}
+	for _, assignee := range assignees {
+		if !assigned[assignee] {
+			if err := m.reportAssigneeDropped(sourceIssue, assignee, "could not be assigned"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *migrator) reportAssigneeDropped(issue *github.Issue, assignee, reason string) error {
+	fmt.Printf("[!!] dropped the assignee %s: %s (%s)\n", assignee, issue.HTMLURL, reason)
+	return m.report(&reportEntry{
+		Type:     "assignee_dropped",
+		URL:      issue.HTMLURL,
+		Location: "assignees",
+		Message:  fmt.Sprintf("dropped %s (%s)", assignee, reason),
+	})
+}
@@ -0,0 +1,62 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestAddAssignees(t *testing.T) {
+	var added []string
+	target := repo.New(github.NewMockClient(
+		github.MockAddAssignees(func(_ string, issueNumber int, assignees []string) (*github.Issue, error) {
+			assert.Equal(t, 3, issueNumber)
+			added = assignees
+			return &github.Issue{
+				Number:    issueNumber,
+				Assignees: []*github.User{{Login: "sample-user-1"}, {Login: "sample-user-2"}},
+			}, nil
+		}),
+	), "example/target")
+	report := new(bytes.Buffer)
+	m := New(nil, target, nil, Report(report)).(*migrator)
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	m.targetMembers = []*github.Member{
+		{Login: "sample-user-1"}, {Login: "sample-user-2"}, {Login: "sample-user-3"},
+	}
+	issue := &github.Issue{
+		Number:  3,
+		HTMLURL: "http://localhost/example/source/issues/3",
+		Assignees: []*github.User{
+			{Login: "sample-user-1"}, {Login: "sample-user-2"},
+			{Login: "sample-user-3"}, {Login: "other-user"},
+		},
+	}
+	require.NoError(t, m.addAssignees(context.Background(), issue))
+	assert.Equal(t, []string{"sample-user-1", "sample-user-2", "sample-user-3"}, added)
+	assert.Equal(t, `{"type":"assignee_dropped","url":"http://localhost/example/source/issues/3","location":"assignees","message":"dropped other-user (not a member of the target)"}
+{"type":"assignee_dropped","url":"http://localhost/example/source/issues/3","location":"assignees","message":"dropped sample-user-3 (could not be assigned)"}
+`, report.String())
+
+	// the assignee sent on importing is skipped
+	added, issue.Assignees = nil, []*github.User{{Login: "sample-user-1"}}
+	m.setImportedAssignee(issue, "sample-user-1")
+	require.NoError(t, m.addAssignees(context.Background(), issue))
+	assert.Nil(t, added)
+
+	// the member is added when the assignee is not sent on importing
+	report.Reset()
+	issue.Assignee = &github.User{Login: "other-user"}
+	issue.Assignees = []*github.User{{Login: "other-user"}, {Login: "sample-user-2"}}
+	m.setImportedAssignee(issue, "")
+	require.NoError(t, m.addAssignees(context.Background(), issue))
+	assert.Equal(t, []string{"sample-user-2"}, added)
+	assert.Equal(t, `{"type":"assignee_dropped","url":"http://localhost/example/source/issues/3","location":"assignees","message":"dropped other-user (not a member of the target)"}
+`, report.String())
+}
@@ -1,6 +1,7 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"html"
 	"strings"
@@ -11,41 +12,51 @@ import (
 
 type builder struct {
 	*migrator
+	ctx            context.Context
 	issue          *github.Issue
 	pullReq        *github.PullReq
 	comments       []*github.Comment
 	events         []*github.Event
 	commits        []*github.Commit
+	localCommits   bool
 	commitDiff     string
+	archivedDiff   *archivedDiff
+	missingDiff    string
 	reviews        []*github.Review
 	reviewComments []*github.ReviewComment
-	skipAssignee   bool
+	provenances    importProvenances
+	degradations   importDegradation
 }
 
-func (m *migrator) buildImport(
+func (m *migrator) buildImport(ctx context.Context,
 	issue *github.Issue, pullReq *github.PullReq,
 	comments []*github.Comment, events []*github.Event,
-	commits []*github.Commit, commitDiff string,
+	commits []*github.Commit, localCommits bool,
+	commitDiff string, archivedDiff *archivedDiff, missingDiff string,
 	reviews []*github.Review, reviewComments []*github.ReviewComment,
-	skipAssignee bool,
+	degradations importDegradation,
 ) (*github.Import, error) {
 	return (&builder{
 		migrator:       m,
+		ctx:            ctx,
 		issue:          issue,
 		pullReq:        pullReq,
 		comments:       comments,
 		events:         events,
 		commits:        commits,
+		localCommits:   localCommits,
 		commitDiff:     commitDiff,
+		archivedDiff:   archivedDiff,
+		missingDiff:    missingDiff,
 		reviews:        reviews,
 		reviewComments: reviewComments,
-		skipAssignee:   skipAssignee,
+		degradations:   degradations,
 	}).build()
 }
 
 func (b *builder) build() (*github.Import, error) {
 	importIssue := &github.ImportIssue{
-		Title:     b.issue.Title,
+		Title:     b.customFilters.apply(b.issue.Title),
 		Body:      b.buildImportBody(),
 		CreatedAt: b.issue.CreatedAt,
 		UpdatedAt: b.issue.UpdatedAt,
@@ -53,8 +64,8 @@ func (b *builder) build() (*github.Import, error) {
 		ClosedAt:  b.issue.ClosedAt,
 		Labels:    b.buildImportLabels(b.issue),
 	}
-	if !b.skipAssignee && b.issue.Assignee != nil {
-		target := b.commentFilters.apply(b.issue.Assignee.Login)
+	if b.issue.Assignee != nil {
+		target := b.targetLogin(b.issue.Assignee.Login)
 		isMember, err := b.isTargetMember(target)
 		if err != nil {
 			return nil, err
@@ -64,7 +75,7 @@ func (b *builder) build() (*github.Import, error) {
 		}
 	}
 	if b.issue.Milestone != nil {
-		if l, ok := b.milestoneByTitle[b.issue.Milestone.Title]; ok {
+		if l, ok := b.milestoneByTitle[b.targetMilestoneTitle(b.issue.Milestone.Title)]; ok {
 			importIssue.Milestone = l.Number
 		}
 	}
@@ -72,7 +83,12 @@ func (b *builder) build() (*github.Import, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &github.Import{Issue: importIssue, Comments: comments}, nil
+	b.provenances.issue = b.newIssueProvenance(b.issue)
+	// the degradations are applied before embedding the provenance metadata,
+	// so that the content hashes match the imported bodies
+	imp := b.degradeImport(&github.Import{Issue: importIssue, Comments: comments},
+		&b.provenances, b.issue, b.degradations)
+	return splitImport(imp, &b.provenances, b.degradations.bodyLength()), nil
 }
 
 func (b *builder) buildImportBody() string {
@@ -89,11 +105,16 @@ func (b *builder) buildImportBody() string {
 	tableRows := [][]string{
 		{
 			b.buildImageTag(b.issue.User, 35),
-			fmt.Sprintf("@%s %s", b.getUserLogin(b.issue.User), action),
+			b.mention(b.getUserLogin(b.issue.User)) + " " + action,
 		},
 	}
 	if len(b.commitDiff) > 0 {
 		tableRows = append(tableRows, []string{b.buildDiffDetails()})
+	} else if b.missingDiff != "" {
+		tableRows = append(tableRows, []string{
+			"The diff is not available from the source repository: " +
+				html.EscapeString(b.missingDiff) + ".",
+		})
 	}
 	if len(b.commits) > 0 {
 		tableRows = append(tableRows, []string{b.buildCommitDetails()})
@@ -109,16 +130,63 @@ func (b *builder) buildDiffDetails() string {
 	if b.pullReq.Deletions > 0 {
 		summary += ", " + plural(b.pullReq.Deletions, "deletion") + "(-)"
 	}
-	return b.buildDetails("  ", summary, "\n```diff\n"+
-		escapeBackQuotes(truncateDiff(b.commitDiff))+
-		"```\n")
+	var note string
+	if a := b.archivedDiff; a != nil {
+		if a.diffURL != "" {
+			note = fmt.Sprintf(`The diff is truncated. See the full <a href="%s">diff</a>`, a.diffURL)
+			if a.patchURL != "" {
+				note += fmt.Sprintf(` and <a href="%s">patch</a>`, a.patchURL)
+			}
+		} else {
+			// the archive is not accessible by URL
+			note = fmt.Sprintf("The diff is truncated. The full diff is archived as <code>%s</code>",
+				html.EscapeString(a.diffName))
+			if a.patchName != "" {
+				note += fmt.Sprintf(" and the patch as <code>%s</code>", html.EscapeString(a.patchName))
+			}
+		}
+		note = "\n" + note + ".\n"
+	}
+	files := mergeDiffFiles(parseDiff(b.commitDiff))
+	truncateDiffFiles(files)
+	if len(files) == 1 && files[0].path == "" {
+		return b.buildDetails("  ", summary, note+"\n```diff\n"+
+			escapeBackQuotes(files[0].String())+
+			"```\n")
+	}
+	var fileRows [][]string
+	for i, f := range files {
+		if i > truncatedTableRows && len(files) > maxTableRows {
+			fileRows = append(fileRows, []string{
+				fmt.Sprintf("more %d files", len(files)-i),
+			})
+			break
+		}
+		fileRows = append(fileRows, b.buildDiffFileRow(f))
+	}
+	return b.buildDetails("  ", summary, note+b.buildTable(3, fileRows...))
+}
+
+func (b *builder) buildDiffFileRow(f *diffFile) []string {
+	path := "<code>" + html.EscapeString(f.path) + "</code>"
+	if f.oldPath != "" {
+		path = "<code>" + html.EscapeString(f.oldPath) + "</code> → " + path
+	}
+	if f.hunks != "" {
+		path = b.buildDetails("", path, "\n```diff\n"+escapeBackQuotes(f.String())+"```\n")
+	}
+	return []string{
+		path,
+		string(f.status),
+		fmt.Sprintf("+%d -%d", f.additions, f.deletions),
+	}
 }
 
 func (b *builder) buildCommitDetails() string {
 	summary := plural(b.pullReq.Commits, "commit")
 	var commitRows [][]string
 	for i, c := range b.commits {
-		if i > 90 && len(b.commits) > 100 {
+		if i > truncatedTableRows && len(b.commits) > maxTableRows {
 			commitRows = append(commitRows, []string{
 				fmt.Sprintf("more %d commits", len(b.commits)-i),
 			})
@@ -129,8 +197,17 @@ func (b *builder) buildCommitDetails() string {
 		if committer == nil {
 			committer = c.Author
 		}
-		if committer == nil {
-			committer = &github.User{Login: c.Commit.Committer.Name}
+		var committerTag string
+		if b.localCommits {
+			// The commits read from the local git repository have no GitHub
+			// users, so the committers are rendered with the plain names.
+			committerTag = html.EscapeString(c.Commit.Committer.Name)
+		} else {
+			if committer == nil {
+				committer = &github.User{Login: c.Commit.Committer.Name}
+			}
+			committerTag = b.buildImageTag(committer, 16) + " " +
+				b.mention(b.targetLogin(committer.Login))
 		}
 		t, err := time.Parse(time.RFC3339, c.Commit.Committer.Date)
 		if err == nil {
@@ -138,9 +215,8 @@ func (b *builder) buildCommitDetails() string {
 		}
 		commitRows = append(commitRows, []string{
 			html.EscapeString(c.Commit.Message) + "<br>\n" +
-				b.buildImageTag(committer, 16) +
-				fmt.Sprintf(" @%s committed%s", b.commentFilters.apply(committer.Login), dateString) +
-				fmt.Sprintf(` <a href="%s">%s</a>`, b.commentFilters.apply(c.HTMLURL), c.SHA[:7]),
+				fmt.Sprintf("%s committed%s", committerTag, dateString) +
+				fmt.Sprintf(` <a href="%s">%s</a>`, b.commentFilters.apply(c.HTMLURL), shortSHA(b.mapCommitSHA(c.SHA))),
 		})
 	}
 	return b.buildDetails("", summary, b.buildTable(1, commitRows...))
@@ -152,15 +228,11 @@ func (b *builder) buildImportComments() ([]*github.ImportComment, error) {
 	if err != nil {
 		return nil, err
 	}
-	reviewComments := b.buildImportReviewComments()
 	importReviews := b.buildImportReviews()
 	return append(
 		append(
-			append(
-				issueComments,
-				eventComments...,
-			),
-			reviewComments...,
+			issueComments,
+			eventComments...,
 		),
 		importReviews...,
 	), nil
@@ -169,49 +241,116 @@ func (b *builder) buildImportComments() ([]*github.ImportComment, error) {
 func (b *builder) buildImportIssueComments() []*github.ImportComment {
 	xs := make([]*github.ImportComment, len(b.comments))
 	for i, c := range b.comments {
-		xs[i] = &github.ImportComment{
+		p := b.newProvenance("comment", c.HTMLURL, c.User)
+		p.SourceIDs = appendSourceID(nil, c.ID)
+		p.CreatedAt, p.UpdatedAt = c.CreatedAt, c.UpdatedAt
+		xs[i] = b.provenances.add(&github.ImportComment{
 			Body:      b.buildUserActionBody(c.User, "commented", c.Body),
 			CreatedAt: c.CreatedAt,
-		}
+		}, p)
 	}
 	return xs
 }
 
+// buildImportReviews builds the reviews, each of which includes the inline
+// threads started in the review. The threads out of the migrated reviews are
+// built as separate comments, followed by the reviews.
 func (b *builder) buildImportReviews() []*github.ImportComment {
-	var xs []*github.ImportComment
-	for _, c := range b.reviews {
+	threads := b.buildReviewThreads()
+	threadsByReviewIDs := make(map[int][]*reviewThread)
+	for _, t := range threads {
+		threadsByReviewIDs[t.reviewID] = append(threadsByReviewIDs[t.reviewID], t)
+	}
+	var reviews []*github.ImportComment
+	reviewIDs := make(map[int]bool)
+	for _, r := range b.reviews {
 		var action string
-		if c.State == github.ReviewStateApproved {
+		switch r.State {
+		case github.ReviewStateApproved:
 			action = "approved"
-		} else if c.State == github.ReviewStateChangesRequested {
+		case github.ReviewStateChangesRequested:
 			action = "requested changes"
-		} else if c.State == github.ReviewStateDismissed {
+		case github.ReviewStateDismissed:
 			action = "commented"
-		} else {
+		case github.ReviewStateCommented:
+			if r.Body == "" && len(threadsByReviewIDs[r.ID]) == 0 {
+				continue
+			}
+			action = "commented"
+		default:
 			continue
 		}
-		xs = append(xs, &github.ImportComment{
-			Body:      b.buildUserActionBody(c.User, action, c.Body),
-			CreatedAt: c.SubmittedAt,
-		})
+		reviewIDs[r.ID] = true
+		p := b.newProvenance("review", r.HTMLURL, r.User)
+		p.SourceIDs = appendSourceID(nil, r.ID)
+		p.CreatedAt = r.SubmittedAt
+		body := b.buildUserActionBody(r.User, action, r.Body)
+		for _, t := range threadsByReviewIDs[r.ID] {
+			body += "\n\n" + t.body
+			p.ReviewCommentIDs = append(p.ReviewCommentIDs, t.ids...)
+		}
+		reviews = append(reviews, b.provenances.add(&github.ImportComment{
+			Body:      body,
+			CreatedAt: r.SubmittedAt,
+		}, p))
 	}
-	return xs
+	var xs []*github.ImportComment
+	for _, t := range threads {
+		if !reviewIDs[t.reviewID] {
+			p := b.newProvenance("review_comment", t.url, t.user)
+			p.ReviewCommentIDs = t.ids
+			p.CreatedAt = t.createdAt
+			xs = append(xs, b.provenances.add(&github.ImportComment{
+				Body:      t.body,
+				CreatedAt: t.createdAt,
+			}, p))
+		}
+	}
+	return append(xs, reviews...)
 }
 
-func (b *builder) buildImportReviewComments() []*github.ImportComment {
-	var xs []*github.ImportComment
+type reviewThread struct {
+	reviewID  int
+	ids       []int
+	url       string
+	user      *github.User
+	body      string
+	createdAt string
+}
+
+// buildReviewThreads builds the inline threads with the diff hunks (trimmed to
+// the commented lines). The replies are included in the threads regardless of
+// their reviews.
+func (b *builder) buildReviewThreads() []*reviewThread {
+	var xs []*reviewThread
 	indexByID := make(map[int]int)
 	for _, c := range b.reviewComments {
 		if i, ok := indexByID[c.InReplyToID]; ok {
 			indexByID[c.ID] = i
-			xs[i].Body += "\n\n" + b.buildUserActionBody(c.User, "commented", c.Body)
+			xs[i].ids = appendSourceID(xs[i].ids, c.ID)
+			xs[i].body += "\n\n" + b.buildUserActionBody(c.User, "commented", buildSuggestions(c))
 			continue
 		}
 		indexByID[c.ID] = len(xs)
-		diffBody := strings.Join([]string{"```diff", "# " + c.Path, c.DiffHunk, "```"}, "\n")
-		xs = append(xs, &github.ImportComment{
-			Body:      diffBody + "\n\n" + b.buildUserActionBody(c.User, "commented", c.Body),
-			CreatedAt: c.CreatedAt,
+		diffBody := strings.Join([]string{"```diff", buildReviewCommentHeader(c), trimDiffHunk(c), "```"}, "\n")
+		action := "commented"
+		commitID := c.CommitID
+		if isOutdatedReviewComment(c) {
+			commitID = c.OriginalCommitID
+		}
+		if commitID != "" {
+			action += " on " + b.buildCommitLinkTag(b.targetRepo, commitID)
+		}
+		if isOutdatedReviewComment(c) {
+			action += " (outdated)"
+		}
+		xs = append(xs, &reviewThread{
+			reviewID:  c.PullRequestReviewID,
+			ids:       appendSourceID(nil, c.ID),
+			url:       c.HTMLURL,
+			user:      c.User,
+			body:      diffBody + "\n\n" + b.buildUserActionBody(c.User, action, buildSuggestions(c)),
+			createdAt: c.CreatedAt,
 		})
 	}
 	return xs
@@ -232,7 +371,7 @@ func (b *builder) buildUserActionBody(user *github.User, action, body string) st
 	}
 	return b.buildTable(2, []string{
 		b.buildImageTag(user, 35),
-		fmt.Sprintf("@%s %s", b.getUserLogin(user), action),
+		b.mention(b.getUserLogin(user)) + " " + action,
 	}) + suffix
 }
 
@@ -301,17 +440,30 @@ func buildIssueLinkTag(repo *github.Repo, issue *github.Issue) string {
 }
 
 func (b *builder) buildCommitLinkTag(repo *github.Repo, sha string) string {
-	return fmt.Sprintf(`<a href="%s/commit/%s">%s</a>`, repo.HTMLURL, sha, sha[:7])
+	sha = b.mapCommitSHA(sha)
+	return fmt.Sprintf(`<a href="%s/commit/%s">%s</a>`, repo.HTMLURL, sha, shortSHA(sha))
 }
 
 func (b *builder) buildCompareLinkTag(repo *github.Repo, base, head string) string {
-	return fmt.Sprintf(`<a href="%s/compare/%s...%s">%s...%s</a>`, repo.HTMLURL, base, head, base[:7], head[:7])
+	base, head = b.mapCommitSHA(base), b.mapCommitSHA(head)
+	return fmt.Sprintf(`<a href="%s/compare/%s...%s">%s...%s</a>`, repo.HTMLURL, base, head, shortSHA(base), shortSHA(head))
+}
+
+// shortSHA abbreviates the commit hash, which can be shorter than expected.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
 }
 
 func (b *builder) buildImportLabels(issue *github.Issue) []string {
 	xs := []string{}
 	for _, l := range issue.Labels {
-		xs = append(xs, l.Name)
+		xs = append(xs, b.targetLabelName(l.Name))
+	}
+	if b.labelOrigin {
+		xs = append(xs, b.sourceRepo.FullName)
 	}
 	return xs
 }
@@ -320,7 +472,7 @@ func (b *builder) isAvailableUser(name string) bool {
 	if name == "ghost" {
 		return true
 	}
-	u, _ := b.lookupUser(name)
+	u, _ := b.lookupUser(b.ctx, name)
 	return u != nil
 }
 
@@ -328,5 +480,5 @@ func (b *builder) getUserLogin(user *github.User) string {
 	if user == nil {
 		return "ghost"
 	}
-	return b.commentFilters.apply(user.Login)
+	return b.targetLogin(user.Login)
 }
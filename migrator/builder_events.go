@@ -19,10 +19,18 @@ func (b *builder) buildImportEventComments() ([]*github.ImportComment, error) {
 			return nil, err
 		}
 		if body != "" {
-			xs = append(xs, &github.ImportComment{
+			p := b.newProvenance("event", b.issue.HTMLURL, getEventUser(eg[0]))
+			for _, e := range eg {
+				p.SourceIDs = appendSourceID(p.SourceIDs, e.ID)
+			}
+			if len(p.SourceIDs) > 0 {
+				p.SourceURL += fmt.Sprintf("#event-%d", p.SourceIDs[0])
+			}
+			p.CreatedAt = eg[0].CreatedAt
+			xs = append(xs, b.provenances.add(&github.ImportComment{
 				Body:      b.buildUserActionBody(getEventUser(eg[0]), body, ""),
 				CreatedAt: eg[0].CreatedAt,
-			})
+			}, p))
 		}
 	}
 	return xs, nil
@@ -37,6 +45,34 @@ func getEventUser(e *github.Event) *github.User {
 	}
 }
 
+// The events listed only in the timeline (the other events are listed by
+// ListEvents, which is used for them to keep the renderings of the events).
+var timelineEventTypes = map[string]bool{
+	"cross-referenced": true,
+}
+
+// mergeTimelineEvents merges the timeline only events into the events in order
+// of the created dates, keeping the order of the events.
+func mergeTimelineEvents(events, timeline []*github.Event) []*github.Event {
+	var xs []*github.Event
+	for _, e := range timeline {
+		if timelineEventTypes[e.Event] {
+			xs = append(xs, e)
+		}
+	}
+	if len(xs) == 0 {
+		return events
+	}
+	merged := make([]*github.Event, 0, len(events)+len(xs))
+	for _, e := range events {
+		for len(xs) > 0 && xs[0].CreatedAt < e.CreatedAt {
+			merged, xs = append(merged, xs[0]), xs[1:]
+		}
+		merged = append(merged, e)
+	}
+	return append(merged, xs...)
+}
+
 func groupEventsByCreated(xs []*github.Event) [][]*github.Event {
 	ess := make([][]*github.Event, 0, len(xs))
 	eventGroupTypes := map[string]int{
@@ -68,6 +104,14 @@ func groupEventsByCreated(xs []*github.Event) [][]*github.Event {
 		"milestoned":               11,
 		"demilestoned":             11,
 		"deployed":                 12,
+		"cross-referenced":         13,
+		"referenced":               14,
+		"connected":                15,
+		"disconnected":             15,
+		"marked_as_duplicate":      16,
+		"unmarked_as_duplicate":    16,
+		"transferred":              17,
+		"comment_deleted":          18,
 	}
 	for _, x := range xs {
 		if _, ok := eventGroupTypes[x.Event]; !ok || getEventUser(x) == nil {
@@ -131,14 +175,22 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 		case "reopened":
 			actions = append(actions, fmt.Sprintf("reopened the %s", b.issue.Type()))
 		case "labeled":
-			addedLabels = append(addedLabels, e.Label.Name)
+			addedLabels = append(addedLabels, b.targetLabelName(e.Label.Name))
 		case "unlabeled":
-			removedLabels = append(removedLabels, e.Label.Name)
+			removedLabels = append(removedLabels, b.targetLabelName(e.Label.Name))
 		case "renamed":
+			from, err := b.filterTitle(e.Rename.From, "renamed title")
+			if err != nil {
+				return "", err
+			}
+			to, err := b.filterTitle(e.Rename.To, "renamed title")
+			if err != nil {
+				return "", err
+			}
 			actions = append(actions,
 				fmt.Sprintf(
 					"changed the title <b><s>%s</s></b> <b>%s</b>",
-					html.EscapeString(e.Rename.From), html.EscapeString(e.Rename.To),
+					html.EscapeString(from), html.EscapeString(to),
 				),
 			)
 		case "head_ref_deleted":
@@ -203,7 +255,7 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 					fmt.Sprintf(
 						`%s from <b>%s</b>`,
 						actionStr,
-						b.commentFilters.apply(e.RequestedTeam.Name),
+						b.targetLogin(e.RequestedTeam.Name),
 					),
 				)
 				break
@@ -232,8 +284,8 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 			if target != nil {
 				actions = append(actions,
 					fmt.Sprintf(
-						`dismissed @%s's review<br>%s`,
-						b.commentFilters.apply(target.Login),
+						`dismissed %s's review<br>%s`,
+						b.mention(b.targetLogin(target.Login)),
 						html.EscapeString(e.DismissedReview.DismissalMessage),
 					),
 				)
@@ -250,7 +302,7 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 		case "convert_to_draft":
 			actions = append(actions, "marked this pull request as draft")
 		case "converted_note_to_issue":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
+			p, err := b.getProject(b.ctx, e.ProjectCard.ProjectID)
 			if err != nil {
 				return "", err
 			}
@@ -262,7 +314,7 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 				),
 			)
 		case "added_to_project":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
+			p, err := b.getProject(b.ctx, e.ProjectCard.ProjectID)
 			if err != nil {
 				return "", err
 			}
@@ -274,7 +326,7 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 				),
 			)
 		case "moved_columns_in_project":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
+			p, err := b.getProject(b.ctx, e.ProjectCard.ProjectID)
 			if err != nil {
 				return "", err
 			}
@@ -287,7 +339,7 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 				),
 			)
 		case "removed_from_project":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
+			p, err := b.getProject(b.ctx, e.ProjectCard.ProjectID)
 			if err != nil {
 				return "", err
 			}
@@ -305,13 +357,13 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 			} else {
 				actionStr = "removed this from"
 			}
-			if m := b.milestoneByTitle[e.Milestone.Title]; m != nil {
+			if m := b.milestoneByTitle[b.targetMilestoneTitle(e.Milestone.Title)]; m != nil {
 				actions = append(actions,
 					fmt.Sprintf(
 						`%s the <b><a href="%s">%s</a></b> milestone`,
 						actionStr,
 						m.HTMLURL,
-						html.EscapeString(e.Milestone.Title),
+						html.EscapeString(m.Title),
 					),
 				)
 			} else {
@@ -325,6 +377,47 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 			}
 		case "deployed":
 			actions = append(actions, `deployed this`)
+		case "cross-referenced":
+			if e.Source == nil || e.Source.Issue == nil {
+				break
+			}
+			tag, err := b.buildCrossReferenceTag(e.Source.Issue)
+			if err != nil {
+				return "", err
+			}
+			actions = append(actions,
+				fmt.Sprintf("mentioned this %s in %s", b.issue.Type(), tag),
+			)
+		case "referenced":
+			if e.CommitID == "" {
+				break
+			}
+			actions = append(actions,
+				fmt.Sprintf(
+					"added a commit that referenced this %s<br>\ncommit %s",
+					b.issue.Type(), b.buildReferencedCommitLinkTag(e),
+				),
+			)
+		case "connected", "disconnected":
+			var target string
+			if b.pullReq == nil {
+				target = "a pull request"
+			} else {
+				target = "an issue"
+			}
+			if e.Event == "connected" {
+				actions = append(actions, "linked "+target+" to this "+b.issue.Type().String())
+			} else {
+				actions = append(actions, "removed a link to "+target)
+			}
+		case "marked_as_duplicate":
+			actions = append(actions, "marked this as a duplicate")
+		case "unmarked_as_duplicate":
+			actions = append(actions, "marked this as not a duplicate")
+		case "transferred":
+			actions = append(actions, fmt.Sprintf("transferred this %s from another repository", b.issue.Type()))
+		case "comment_deleted":
+			actions = append(actions, "deleted a comment")
 		}
 	}
 
@@ -364,11 +457,63 @@ func (b *builder) mentionAll(users []*github.User) string {
 		if i > 0 {
 			s += " "
 		}
-		s += "@" + b.commentFilters.apply(u.Login)
+		s += b.mention(b.targetLogin(u.Login))
 	}
 	return s
 }
 
+// buildCrossReferenceTag builds the link to the referencing issue. The issues
+// in the source repository (and the mapped repositories) link to the target.
+func (b *builder) buildCrossReferenceTag(issue *github.Issue) (string, error) {
+	url := b.commentFilters.apply(issue.HTMLURL)
+	number := issue.Number
+	if strings.HasPrefix(issue.HTMLURL, b.sourceRepo.HTMLURL+"/") {
+		if n := b.targetIssueNumber(number); n > 0 {
+			number = n
+		}
+	}
+	ref := fmt.Sprintf("#%d", number)
+	if !strings.HasPrefix(url, b.targetRepo.HTMLURL+"/") {
+		if xs := strings.Split(url, "/"); len(xs) >= 5 {
+			ref = xs[len(xs)-4] + "/" + xs[len(xs)-3] + ref
+		}
+	}
+	title, err := b.filterTitle(issue.Title, "cross-reference title")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		`<a href="%s">%s</a> <b>%s</b>`,
+		url, html.EscapeString(ref), html.EscapeString(title),
+	), nil
+}
+
+// filterTitle scrubs the secrets in the title in the events (the titles are
+// not scanned along with the issue), and applies the custom filters.
+func (b *builder) filterTitle(title, location string) (string, error) {
+	if err := b.scrubSecret(&title, b.issue.HTMLURL, location); err != nil {
+		return "", err
+	}
+	return b.customFilters.apply(title), nil
+}
+
+// buildReferencedCommitLinkTag builds the link to the referencing commit. The
+// commits in the source repository link to the target (with commit mapping).
+func (b *builder) buildReferencedCommitLinkTag(e *github.Event) string {
+	if e.CommitURL == "" || strings.Contains(e.CommitURL, "/repos/"+b.sourceRepo.FullName+"/commits/") {
+		return b.buildCommitLinkTag(b.targetRepo, e.CommitID)
+	}
+	// https://api.github.com/repos/owner/repo/commits/sha
+	xs := strings.Split(e.CommitURL, "/")
+	if len(xs) < 5 {
+		return fmt.Sprintf("<code>%s</code>", shortSHA(e.CommitID))
+	}
+	fullName := xs[len(xs)-4] + "/" + xs[len(xs)-3]
+	baseURL := strings.TrimSuffix(b.sourceRepo.HTMLURL, b.sourceRepo.FullName)
+	url := b.commentFilters.apply(baseURL + fullName + "/commit/" + e.CommitID)
+	return fmt.Sprintf(`<a href="%s">%s@%s</a>`, url, html.EscapeString(fullName), shortSHA(e.CommitID))
+}
+
 func quoteLabels(xs []string) string {
 	ys := make([]string, len(xs))
 	for i, x := range xs {
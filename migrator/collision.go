@@ -0,0 +1,49 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// issueCollisionError represents that the target issue of the same number is
+// not imported from the source issue. The migration cannot continue, since the
+// references of the following issues are mismatched.
+type issueCollisionError struct {
+	sourceURL, targetURL string
+}
+
+func (err *issueCollisionError) Error() string {
+	return fmt.Sprintf(
+		"issue number collision: %s is not imported from %s (the target repository has other issues)",
+		err.targetURL, err.sourceURL,
+	)
+}
+
+// isImportedFrom reports whether the target issue is imported from the source
+// issue, by the provenance metadata or the link to the source issue (for the
+// issues imported by the older versions).
+func (m *migrator) isImportedFrom(targetIssue, sourceIssue *github.Issue) bool {
+	if p, _, ok := parseProvenance(targetIssue.Body); ok {
+		return p.SourceURL == sourceIssue.HTMLURL
+	}
+	return strings.Contains(targetIssue.Body, buildIssueLinkTag(m.sourceRepo, sourceIssue))
+}
+
+func (m *migrator) checkIssueCollision(targetIssue, sourceIssue *github.Issue) error {
+	if m.isImportedFrom(targetIssue, sourceIssue) {
+		return nil
+	}
+	err := &issueCollisionError{sourceURL: sourceIssue.HTMLURL, targetURL: targetIssue.HTMLURL}
+	fmt.Printf("[!!] %s\n", err)
+	if err := m.report(&reportEntry{
+		Type:     "issue_collision",
+		URL:      sourceIssue.HTMLURL,
+		Location: "number",
+		Message:  fmt.Sprintf("%s is not imported from the issue", targetIssue.HTMLURL),
+	}); err != nil {
+		return err
+	}
+	return err
+}
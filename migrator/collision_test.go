@@ -0,0 +1,75 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestMigrateIssuesCollision(t *testing.T) {
+	var imported bool
+	source := repo.New(github.NewMockClient(
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{
+				{
+					Number:  1,
+					Title:   "Example title 1",
+					HTMLURL: "http://localhost/example/source/issues/1",
+					User:    &github.User{Login: "sample-user-1"},
+				},
+			})
+		}),
+	), "example/source")
+	target := repo.New(github.NewMockClient(
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{
+				{
+					Number:  1,
+					Title:   "Unrelated issue",
+					Body:    "This issue was created in the target repository.",
+					HTMLURL: "http://localhost/example/target/issues/1",
+				},
+			})
+		}),
+		github.MockImport(func(string, *github.Import) (*github.ImportResult, error) {
+			imported = true
+			return &github.ImportResult{ID: 12345, Status: "pending"}, nil
+		}),
+	), "example/target")
+
+	report, queue := new(bytes.Buffer), new(bytes.Buffer)
+	m := New(source, target, nil, Report(report), IssueErrorPolicy(ErrorPolicyPlaceholder, queue)).(*migrator)
+	m.sourceRepo = &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	err := m.migrateIssues(context.Background())
+	var collisionErr *issueCollisionError
+	require.True(t, errors.As(err, &collisionErr))
+	assert.EqualError(t, err, "issue number collision: "+
+		"http://localhost/example/target/issues/1 is not imported from "+
+		"http://localhost/example/source/issues/1 (the target repository has other issues)")
+	assert.False(t, imported)
+	assert.Equal(t, "", queue.String())
+	assert.Equal(t, `{"type":"issue_collision","url":"http://localhost/example/source/issues/1","location":"number","message":"http://localhost/example/target/issues/1 is not imported from the issue"}
+`, report.String())
+}
+
+func TestIsImportedFrom(t *testing.T) {
+	m := New(nil, nil, nil).(*migrator)
+	m.sourceRepo = &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	sourceIssue := &github.Issue{Number: 1, HTMLURL: "http://localhost/example/source/issues/1"}
+	p := &provenance{SourceURL: sourceIssue.HTMLURL}
+	assert.True(t, m.isImportedFrom(&github.Issue{Body: p.embed("body", 0)}, sourceIssue))
+	p = &provenance{SourceURL: "http://localhost/example/source/issues/2"}
+	assert.False(t, m.isImportedFrom(&github.Issue{Body: p.embed("body", 0)}, sourceIssue))
+	assert.True(t, m.isImportedFrom(&github.Issue{
+		Body: "imported from " + buildIssueLinkTag(m.sourceRepo, sourceIssue),
+	}, sourceIssue))
+	assert.False(t, m.isImportedFrom(&github.Issue{Body: "other issue"}, sourceIssue))
+}
@@ -1,8 +1,10 @@
 package migrator
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
@@ -10,7 +12,19 @@ import (
 
 type commentFilter func(string) string
 
-func newRepoURLFilter(sourceRepo, targetRepo *github.Repo) commentFilter {
+// CommentFilter returns a migrator option to register a custom filter. The
+// filters are applied in order to the titles, bodies, comments, project notes
+// and milestone descriptions, after the links are rewritten.
+func CommentFilter(f func(string) string) Option {
+	return func(m *migrator) {
+		m.customFilters = append(m.customFilters, commentFilter(f))
+	}
+}
+
+// newRepoURLFilter creates a filter to rewrite the links to the source
+// repository. When the issue numbers are rewritten by newIssueNumberFilter,
+// the links to the issues left in the source are not rewritten.
+func newRepoURLFilter(sourceRepo, targetRepo *github.Repo, numbered bool) commentFilter {
 	sourceURL, _ := url.Parse(sourceRepo.HTMLURL)
 	targetURL, _ := url.Parse(targetRepo.HTMLURL)
 	replaceImageLinks := sourceURL.Scheme != targetURL.Scheme || sourceURL.Host != targetURL.Host
@@ -20,8 +34,20 @@ func newRepoURLFilter(sourceRepo, targetRepo *github.Repo) commentFilter {
 		imageMarkdownPattern = regexp.MustCompile(`(?i)!\[[^]]*\]\((` + urlPatten + `)\)`)
 		imageHTMLPattern = regexp.MustCompile(`(?i)<img [^<>]*\bsrc="(` + urlPatten + `)"[^<>]*>`)
 	}
+	issueURLPattern := regexp.MustCompile(
+		regexp.QuoteMeta(sourceRepo.HTMLURL) + `(/(?:issues|pull)/\d+\b)?`,
+	)
 	return commentFilter(func(src string) string {
-		src = strings.ReplaceAll(src, sourceRepo.HTMLURL, targetRepo.HTMLURL)
+		if numbered {
+			src = issueURLPattern.ReplaceAllStringFunc(src, func(s string) string {
+				if s != sourceRepo.HTMLURL {
+					return s
+				}
+				return targetRepo.HTMLURL
+			})
+		} else {
+			src = strings.ReplaceAll(src, sourceRepo.HTMLURL, targetRepo.HTMLURL)
+		}
 		if replaceImageLinks {
 			src = imageMarkdownPattern.ReplaceAllString(src, `<a href="$1">$0</a>`)
 			src = imageHTMLPattern.ReplaceAllString(src, `<a href="$1">$0</a>`)
@@ -64,6 +90,153 @@ func newUserMappingFilter(userMapping map[string]string, targetRepo *github.Repo
 	})
 }
 
+// RepoMapping returns a migrator option to rewrite the references to the other
+// repositories migrated along with the repository (for example, when the whole
+// organization moves). The keys and values are the full names of the source
+// and target repositories.
+func RepoMapping(repoMapping map[string]string) Option {
+	return func(m *migrator) {
+		m.repoMapping = repoMapping
+	}
+}
+
+// newRepoMappingFilter creates a filter to rewrite the references to the
+// repositories in the mapping. The references to the issues of the numbered
+// repositories are not rewritten, since they are rewritten (or kept in the
+// source) by newIssueNumberFilter.
+func newRepoMappingFilter(
+	repoMapping map[string]string, sourceRepo, targetRepo *github.Repo, numberedRepos []string,
+) commentFilter {
+	if len(repoMapping) == 0 {
+		return commentFilter(func(src string) string {
+			return src
+		})
+	}
+	sourceURL, _ := url.Parse(sourceRepo.HTMLURL)
+	targetURL, _ := url.Parse(targetRepo.HTMLURL)
+	sourceOwner := strings.Split(sourceRepo.FullName, "/")[0]
+	mapping := make(map[string]string, len(repoMapping)+1)
+	fullNames := make(map[string]string, len(repoMapping)+1)
+	urlNames := make([]string, 0, len(repoMapping))
+	refNames := make([]string, 0, 2*len(repoMapping)+2)
+	for _, xs := range append(
+		[][2]string{{sourceRepo.FullName, targetRepo.FullName}},
+		mapToPairs(repoMapping)...,
+	) {
+		from := strings.ToLower(xs[0])
+		if _, ok := mapping[from]; ok {
+			continue
+		}
+		mapping[from], fullNames[from] = xs[1], from
+		if from != strings.ToLower(sourceRepo.FullName) {
+			// the links to the source repository are handled by newRepoURLFilter
+			urlNames = append(urlNames, regexp.QuoteMeta(xs[0]))
+		}
+		refNames = append(refNames, regexp.QuoteMeta(xs[0]))
+		if owner, name := splitFullName(xs[0]); strings.EqualFold(owner, sourceOwner) {
+			mapping[strings.ToLower(name)], fullNames[strings.ToLower(name)] = xs[1], from
+			refNames = append(refNames, regexp.QuoteMeta(name))
+		}
+	}
+	var urlPattern *regexp.Regexp
+	if len(urlNames) > 0 {
+		urlPattern = regexp.MustCompile(
+			`(?i)` + regexp.QuoteMeta(sourceURL.Scheme+"://"+sourceURL.Host) +
+				`/(` + strings.Join(urlNames, "|") + `)(/(?:issues|pull)/\d+)?([^\w.-]|$)`,
+		)
+	}
+	numbered := make(map[string]bool, len(numberedRepos))
+	for _, name := range numberedRepos {
+		numbered[strings.ToLower(name)] = true
+	}
+	refPattern := regexp.MustCompile(
+		`(?i)(^|[^\w/.-])(` + strings.Join(refNames, "|") + `)(#\d+|@[0-9a-f]{7,40})\b`,
+	)
+	targetBaseURL := targetURL.Scheme + "://" + targetURL.Host
+	return commentFilter(func(src string) string {
+		if urlPattern != nil {
+			src = urlPattern.ReplaceAllStringFunc(src, func(s string) string {
+				xs := urlPattern.FindStringSubmatch(s)
+				if xs[2] != "" && numbered[fullNames[strings.ToLower(xs[1])]] {
+					return s
+				}
+				return targetBaseURL + "/" + mapping[strings.ToLower(xs[1])] + xs[2] + xs[3]
+			})
+		}
+		return refPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := refPattern.FindStringSubmatch(s)
+			if strings.HasPrefix(xs[3], "#") && numbered[fullNames[strings.ToLower(xs[2])]] {
+				return s
+			}
+			return xs[1] + mapping[strings.ToLower(xs[2])] + xs[3]
+		})
+	})
+}
+
+func mapToPairs(m map[string]string) [][2]string {
+	xs := make([][2]string, 0, len(m))
+	for k, v := range m {
+		xs = append(xs, [2]string{k, v})
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i][0] < xs[j][0]
+	})
+	return xs
+}
+
+func splitFullName(fullName string) (string, string) {
+	if i := strings.IndexByte(fullName, '/'); i >= 0 {
+		return fullName[:i], fullName[i+1:]
+	}
+	return "", fullName
+}
+
+// SuppressMentions returns a migrator option to neutralize the mentions in the
+// imported issues and comments so that the users are not notified. The users
+// in the allowlist are mentioned as usual.
+func SuppressMentions(allowlist []string) Option {
+	return func(m *migrator) {
+		m.suppressMentions = true
+		m.mentionAllowlist = allowlist
+	}
+}
+
+// Code blocks and code spans are matched to skip the mentions inside them.
+var mentionPattern = regexp.MustCompile(
+	"(?s)```.*?```|`[^`\n]*`|" +
+		`(^|[^\w\x60/@.-])@([a-zA-Z0-9](?:[a-zA-Z0-9]|-[a-zA-Z0-9])*)(/[a-zA-Z0-9_.-]+)?`,
+)
+
+func newMentionFilter(allowlist []string, targetRepo *github.Repo) commentFilter {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, name := range allowlist {
+		allowed[strings.ToLower(name)] = true
+	}
+	targetURL, _ := url.Parse(targetRepo.HTMLURL)
+	baseURL := targetURL.Scheme + "://" + targetURL.Host
+	return commentFilter(func(src string) string {
+		return mentionPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := mentionPattern.FindStringSubmatch(s)
+			if xs[2] == "" || allowed[strings.ToLower(xs[2]+xs[3])] {
+				return s
+			}
+			href := baseURL + "/" + xs[2]
+			if xs[3] != "" {
+				href = baseURL + "/orgs/" + xs[2] + "/teams" + xs[3]
+			}
+			// a zero width space prevents the mention from notifying the user
+			return fmt.Sprintf(`%s<a href="%s">@&#8203;%s%s</a>`, xs[1], href, xs[2], xs[3])
+		})
+	})
+}
+
+func (m *migrator) mention(login string) string {
+	if m.mentionFilter == nil {
+		return "@" + login
+	}
+	return m.mentionFilter("@" + login)
+}
+
 func buildPattern(xs []string) string {
 	var pattern strings.Builder
 	pattern.WriteString(`\b(`)
@@ -0,0 +1,170 @@
+package migrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestMentionFilter(t *testing.T) {
+	testCases := []struct {
+		src, expected string
+	}{
+		{
+			src:      "@sample-user-1 Thanks.",
+			expected: `<a href="https://github.com/sample-user-1">@&#8203;sample-user-1</a> Thanks.`,
+		},
+		{
+			src:      "cc: @sample-user-1, @sample-user-2 and @example/team",
+			expected: `cc: <a href="https://github.com/sample-user-1">@&#8203;sample-user-1</a>, @sample-user-2 and <a href="https://github.com/orgs/example/teams/team">@&#8203;example/team</a>`,
+		},
+		{
+			src:      "Send to user@example.com.",
+			expected: "Send to user@example.com.",
+		},
+		{
+			src:      "Run `npm i @types/node`.\n```\n@sample-user-1\n```\n@sample-user-1",
+			expected: "Run `npm i @types/node`.\n```\n@sample-user-1\n```\n" + `<a href="https://github.com/sample-user-1">@&#8203;sample-user-1</a>`,
+		},
+	}
+	filter := newMentionFilter([]string{"Sample-User-2"}, &github.Repo{
+		HTMLURL: "https://github.com/example/target",
+	})
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, filter(tc.src))
+	}
+}
+
+func TestRepoMappingFilter(t *testing.T) {
+	testCases := []struct {
+		src, expected string
+	}{
+		{
+			src:      "See http://localhost/example/other/issues/1 and http://localhost/example/other-2/pull/2.",
+			expected: "See https://github.com/new-example/other/issues/1 and http://localhost/example/other-2/pull/2.",
+		},
+		{
+			src:      "Fixed in http://localhost/Example/Other/commit/0123456789abcdef (http://localhost/example/other/compare/0123456...89abcde).",
+			expected: "Fixed in https://github.com/new-example/other/commit/0123456789abcdef (https://github.com/new-example/other/compare/0123456...89abcde).",
+		},
+		{
+			src:      "Ref: other#12, example/other#13, example/other@0123456789, another/repo#14, example/source#15, example/other-2#16",
+			expected: "Ref: new-example/other#12, new-example/other#13, new-example/other@0123456789, new-another/repo#14, new-example/target#15, example/other-2#16",
+		},
+		{
+			src:      "Not a reference: repo#1, another/other#2, http://example.com/example/other#3",
+			expected: "Not a reference: repo#1, another/other#2, http://example.com/example/other#3",
+		},
+	}
+	filter := newRepoMappingFilter(map[string]string{
+		"example/other": "new-example/other",
+		"another/repo":  "new-another/repo",
+	}, &github.Repo{
+		FullName: "example/source",
+		HTMLURL:  "http://localhost/example/source",
+	}, &github.Repo{
+		FullName: "new-example/target",
+		HTMLURL:  "https://github.com/new-example/target",
+	}, nil)
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, filter(tc.src))
+	}
+
+	// the references to the issues of the numbered repositories are kept
+	filter = newRepoMappingFilter(map[string]string{
+		"example/other": "new-example/target",
+	}, &github.Repo{
+		FullName: "example/source",
+		HTMLURL:  "http://localhost/example/source",
+	}, &github.Repo{
+		FullName: "new-example/target",
+		HTMLURL:  "https://github.com/new-example/target",
+	}, []string{"example/source", "example/other"})
+	assert.Equal(t,
+		"example/source#3 other#3 new-example/target@0123456 "+
+			"http://localhost/example/other/issues/3 https://github.com/new-example/target/pulls",
+		filter("example/source#3 other#3 example/other@0123456 "+
+			"http://localhost/example/other/issues/3 http://localhost/example/other/pulls"),
+	)
+}
+
+func TestCommitMappingFilter(t *testing.T) {
+	commitMapping, err := ReadCommitMapping(strings.NewReader(`old                                      new
+0123456789abcdef0123456789abcdef01234567 89abcdef0123456789abcdef0123456789abcdef
+0123456700000000000000000000000000000000 fedcba9876543210fedcba9876543210fedcba98
+fedcba9876543210fedcba9876543210fedcba98 0000000000000000000000000000000000000000
+`))
+	require.NoError(t, err)
+	assert.Len(t, commitMapping, 2)
+	testCases := []struct {
+		src, expected string
+	}{
+		{
+			src:      "Fixed in 0123456789abcdef0123456789abcdef01234567.",
+			expected: "Fixed in 89abcdef0123456789abcdef0123456789abcdef.",
+		},
+		{
+			src:      "Fixed in 0123456789a, 0123456 (ambiguous) and fedcba9 (pruned).",
+			expected: "Fixed in 89abcdef012, 0123456 (ambiguous) and fedcba9 (pruned).",
+		},
+		{
+			src:      "See http://localhost/example/target/compare/01234567000...0123456789abc.",
+			expected: "See http://localhost/example/target/compare/fedcba98765...89abcdef01234.",
+		},
+		{
+			src:      "Order 0123456700 is fixed in `0123456700`.",
+			expected: "Order 0123456700 is fixed in `fedcba9876`.",
+		},
+	}
+	filter := newCommitMappingFilter(newCommitMapper(commitMapping))
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, filter(tc.src))
+	}
+}
+
+func TestReplaceRules(t *testing.T) {
+	rules, err := ReadReplaceRules(strings.NewReader(`
+- pattern: '\bJIRA-(\d+)\b'
+  replace: '[JIRA-$1](https://jira.example.com/browse/JIRA-$1)'
+- pattern: '\b[a-z0-9-]+\.internal\.example\.com\b'
+  replace: '<internal host>'
+`))
+	require.NoError(t, err)
+	m := &migrator{}
+	ReplaceRules(rules)(m)
+	assert.Equal(t,
+		"See [JIRA-123](https://jira.example.com/browse/JIRA-123) (deployed to <internal host>).",
+		m.customFilters.apply("See JIRA-123 (deployed to app-1.internal.example.com)."),
+	)
+
+	_, err = ReadReplaceRules(strings.NewReader(`[{"pattern": "("}]`))
+	assert.EqualError(t, err, "invalid rule at index 0: error parsing regexp: missing closing ): `(`")
+}
+
+func TestTargetLogin(t *testing.T) {
+	cli := github.NewMockClient(
+		github.MockGetRepo(func(path string) (*github.Repo, error) {
+			return &github.Repo{FullName: path, HTMLURL: "http://localhost/" + path}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice(nil)
+		}),
+	)
+	m := New(repo.New(cli, "example/source"), repo.New(cli, "example/target"),
+		map[string]string{"sample-user-1": "target-user-1"},
+		CommentFilter(func(src string) string {
+			return strings.ReplaceAll(src, "user", "person")
+		}),
+	).(*migrator)
+	require.NoError(t, m.init(context.Background()))
+	// the custom filters are applied to the texts, but not to the logins
+	assert.Equal(t, "target-user-1", m.targetLogin("sample-user-1"))
+	assert.Equal(t, "sample-user-2", m.targetLogin("sample-user-2"))
+	assert.Equal(t, "target-person-1 and sample-person-2", m.commentFilters.apply("sample-user-1 and sample-user-2"))
+}
@@ -0,0 +1,112 @@
+package migrator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CommitMapping returns a migrator option to rewrite the commit hashes. This is
+// useful when the git history is rewritten before pushing to the target.
+func CommitMapping(commitMapping map[string]string) Option {
+	return func(m *migrator) {
+		m.commitMapper = newCommitMapper(commitMapping)
+	}
+}
+
+// ReadCommitMapping reads the commit mapping in the format of the commit-map
+// file of git-filter-repo; each line contains the old and new commit hashes.
+func ReadCommitMapping(r io.Reader) (map[string]string, error) {
+	m := make(map[string]string)
+	s := bufio.NewScanner(r)
+	for i := 1; s.Scan(); i++ {
+		xs := strings.Fields(s.Text())
+		if len(xs) == 0 || xs[0] == "old" && i == 1 {
+			continue
+		}
+		if len(xs) != 2 || !isCommitSHA(xs[0]) || !isCommitSHA(xs[1]) {
+			return nil, fmt.Errorf("invalid commit mapping at line %d: %q", i, s.Text())
+		}
+		if strings.Trim(xs[1], "0") == "" {
+			continue // the commit is pruned
+		}
+		m[strings.ToLower(xs[0])] = strings.ToLower(xs[1])
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}(?:[0-9a-fA-F]{24})?$`)
+
+func isCommitSHA(s string) bool {
+	return commitSHAPattern.MatchString(s)
+}
+
+type commitMapper struct {
+	mapping map[string]string
+	shas    []string
+}
+
+func newCommitMapper(mapping map[string]string) *commitMapper {
+	shas := make([]string, 0, len(mapping))
+	for sha := range mapping {
+		shas = append(shas, sha)
+	}
+	sort.Strings(shas)
+	return &commitMapper{mapping: mapping, shas: shas}
+}
+
+// lookup finds the new commit hash by the (possibly abbreviated) old hash.
+// The abbreviated hash is mapped only when it is unique in the mapping.
+func (cm *commitMapper) lookup(sha string) (string, bool) {
+	if cm == nil || len(sha) < 7 {
+		return "", false
+	}
+	if s, ok := cm.mapping[sha]; ok {
+		return s, true
+	}
+	i := sort.SearchStrings(cm.shas, sha)
+	if i == len(cm.shas) || !strings.HasPrefix(cm.shas[i], sha) ||
+		i+1 < len(cm.shas) && strings.HasPrefix(cm.shas[i+1], sha) {
+		return "", false
+	}
+	return cm.mapping[cm.shas[i]][:len(sha)], true
+}
+
+func (m *migrator) mapCommitSHA(sha string) string {
+	if s, ok := m.commitMapper.lookup(sha); ok {
+		return s
+	}
+	return sha
+}
+
+// The hashes consisting only of digits (which are likely to be other numbers
+// like timestamps) are rewritten only in the links and code spans.
+var bareCommitSHAPattern = regexp.MustCompile(
+	`(/commits?/|/compare/|\.\.\.?|\x60)?\b([0-9a-f]{7,64})\b`,
+)
+
+func newCommitMappingFilter(cm *commitMapper) commentFilter {
+	if cm == nil {
+		return commentFilter(func(src string) string {
+			return src
+		})
+	}
+	return commentFilter(func(src string) string {
+		return bareCommitSHAPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := bareCommitSHAPattern.FindStringSubmatch(s)
+			if xs[1] == "" && !strings.ContainsAny(xs[2], "abcdef") {
+				return s
+			}
+			if sha, ok := cm.lookup(xs[2]); ok {
+				return xs[1] + sha
+			}
+			return s
+		})
+	})
+}
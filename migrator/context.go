@@ -0,0 +1,23 @@
+package migrator
+
+import (
+	"context"
+	"time"
+)
+
+// withoutCancel returns a context which is not canceled with the parent. The
+// imports in flight are submitted and polled with this context, so that they
+// are completed on the graceful shutdown to keep the issue numbers aligned.
+func withoutCancel(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
+
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
@@ -10,60 +10,151 @@ const (
 	totalTruncateLength = 60000
 )
 
-// Since import fails on too large diff, truncate it.
-// You may wonder building the diff from the api (without vnd.github.v3.diff header),
-// but it's impossible to build the complete diff even if the changes are small.
-func truncateDiff(diff string) string {
-	s := new(strings.Builder)
-	var i, j int
-	for {
-		i = strings.Index(diff, "\nindex ")
+type diffFileStatus string
+
+const (
+	diffFileStatusAdded    diffFileStatus = "added"
+	diffFileStatusModified diffFileStatus = "modified"
+	diffFileStatusRenamed  diffFileStatus = "renamed"
+	diffFileStatusDeleted  diffFileStatus = "deleted"
+	diffFileStatusBinary   diffFileStatus = "binary"
+)
+
+type diffFile struct {
+	path, oldPath        string
+	status               diffFileStatus
+	additions, deletions int
+	header, hunks        string
+	truncated            bool
+}
+
+// parseDiff splits the diff into the files. Concatenating the headers and the
+// hunks of the files results in the original diff.
+func parseDiff(diff string) []*diffFile {
+	var files []*diffFile
+	for len(diff) > 0 {
+		i := strings.Index(diff, "\ndiff ")
+		var src string
 		if i < 0 {
-			if len(diff) > truncateLength {
-				s.WriteString("Too large diff\n")
-				break
-			}
-			s.WriteString(diff)
-			break
+			src, diff = diff, ""
+		} else {
+			src, diff = diff[:i+1], diff[i+1:]
 		}
-		i++ // newline
+		files = append(files, parseDiffFile(src))
+	}
+	return files
+}
 
-		j = strings.Index(diff[i:], "\n")
-		if j < 0 {
-			if len(diff) > truncateLength {
-				s.WriteString("Too large diff\n")
-				break
+func parseDiffFile(src string) *diffFile {
+	f := &diffFile{status: diffFileStatusModified}
+	if i := strings.Index(src, "\n@@ "); i >= 0 {
+		f.header, f.hunks = src[:i+1], src[i+1:]
+	} else {
+		f.header = src
+	}
+	for _, line := range strings.Split(f.header, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if i := strings.Index(line, " b/"); i >= 0 {
+				f.path = line[i+3:]
 			}
-			s.WriteString(diff)
-			break
+		case strings.HasPrefix(line, "--- a/"):
+			f.oldPath = line[6:]
+		case strings.HasPrefix(line, "+++ b/"):
+			f.path = line[6:]
+		case strings.HasPrefix(line, "rename from "):
+			f.oldPath = line[12:]
+			f.status = diffFileStatusRenamed
+		case strings.HasPrefix(line, "rename to "):
+			f.path = line[10:]
+		case strings.HasPrefix(line, "new file mode "):
+			f.status = diffFileStatusAdded
+		case strings.HasPrefix(line, "deleted file mode "):
+			f.status = diffFileStatusDeleted
+		case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+			f.status = diffFileStatusBinary
 		}
-		j++ // newline
-		s.WriteString(diff[:i+j])
-		diff = diff[i+j:]
+	}
+	if f.status == diffFileStatusDeleted && f.oldPath != "" {
+		f.path = f.oldPath
+	}
+	if f.status != diffFileStatusRenamed {
+		f.oldPath = ""
+	}
+	for _, line := range strings.Split(f.hunks, "\n") {
+		if strings.HasPrefix(line, "+") {
+			f.additions++
+		} else if strings.HasPrefix(line, "-") {
+			f.deletions++
+		}
+	}
+	return f
+}
 
-		i = strings.Index(diff, "\ndiff ")
-		if i < 0 {
-			if len(diff) > truncateLength {
-				s.WriteString("Too large diff\n")
-				break
-			}
-			s.WriteString(diff)
-			break
+// mergeDiffFiles merges the files of the same path into the first one, since
+// the diff joining the diffs of the commits contains the files repeatedly.
+func mergeDiffFiles(files []*diffFile) []*diffFile {
+	var merged []*diffFile
+	indices := make(map[string]int)
+	for _, f := range files {
+		i, ok := indices[f.path]
+		if !ok || f.path == "" {
+			indices[f.path] = len(merged)
+			merged = append(merged, f)
+			continue
+		}
+		g := merged[i]
+		switch {
+		case f.status == diffFileStatusDeleted:
+			g.status = diffFileStatusDeleted
+		case g.status == diffFileStatusDeleted:
+			g.status = diffFileStatusModified
+		case f.status == diffFileStatusBinary:
+			g.status = diffFileStatusBinary
 		}
-		i++ // newline
-		if i > truncateLength {
-			s.WriteString("Too large diff\n")
-			diff = diff[i:]
+		g.additions += f.additions
+		g.deletions += f.deletions
+		g.hunks += f.header + f.hunks
+	}
+	return merged
+}
+
+func (f *diffFile) String() string {
+	if f.truncated {
+		if f.path == "" {
+			return "Too large diff\n"
+		}
+		return f.header + "Too large diff of " + f.path + "\n"
+	}
+	return f.header + f.hunks
+}
+
+// Since import fails on too large diff, truncate it.
+// You may wonder building the diff from the api (without vnd.github.v3.diff header),
+// but it's impossible to build the complete diff even if the changes are small.
+// The hunks of the large files are dropped, and so are the hunks of the files
+// which do not fit in the total length limit.
+func truncateDiffFiles(files []*diffFile) {
+	var length int
+	for _, f := range files {
+		size := len(f.header) + len(f.hunks)
+		if len(f.hunks) > truncateLength || f.hunks == "" && size > truncateLength ||
+			length+size > totalTruncateLength {
+			f.truncated = true
 			continue
 		}
-		s.WriteString(diff[:i])
-		diff = diff[i:]
+		length += size
 	}
-	str := s.String()
-	if len(str) > totalTruncateLength {
-		str = str[:totalTruncateLength] + "\n\nToo large diff\n"
+}
+
+func truncateDiff(diff string) string {
+	files := parseDiff(diff)
+	truncateDiffFiles(files)
+	s := new(strings.Builder)
+	for _, f := range files {
+		s.WriteString(f.String())
 	}
-	return str
+	return s.String()
 }
 
 var backquoteRe = regexp.MustCompile("((?:^|\n) *)```")
@@ -0,0 +1,203 @@
+package migrator
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// DiffArchive represents a storage of the diffs of pull requests, which are
+// too large to be imported to the issues.
+type DiffArchive interface {
+	// Store the content with the name and returns the URL to link (or an empty
+	// string when the content is not accessible by URL).
+	Store(ctx context.Context, name, content string) (string, error)
+}
+
+// ArchiveDiffs returns a migrator option to store the full diffs and patches
+// of the pull requests when they are truncated in the imported issues.
+func ArchiveDiffs(archive DiffArchive) Option {
+	return func(m *migrator) {
+		m.diffArchive = archive
+	}
+}
+
+// NewLocalDiffArchive creates a DiffArchive to store the files in the local
+// directory. The links are built with the base URL, so that the directory can
+// be served somewhere. Without the base URL, the files are not linked.
+func NewLocalDiffArchive(dir, baseURL string) DiffArchive {
+	return &localDiffArchive{dir: dir, baseURL: baseURL}
+}
+
+type localDiffArchive struct {
+	dir, baseURL string
+}
+
+func (a *localDiffArchive) Store(ctx context.Context, name, content string) (string, error) {
+	path := filepath.Join(a.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	if a.baseURL == "" {
+		return "", nil
+	}
+	return strings.TrimSuffix(a.baseURL, "/") + "/" + name, nil
+}
+
+// NewBranchDiffArchive creates a DiffArchive to commit the files to the branch
+// of the repository. The branch is created without any parent commit when it
+// does not exist, and it does not share the history with the other branches.
+// The repository should have at least one commit, since the API to create the
+// commits is not available for the empty repositories. The archive caches the
+// head of the branch, so it should be shared by the migrators archiving to the
+// same branch (for example, on merging the repositories).
+func NewBranchDiffArchive(repo *repo.Repo, branch string) DiffArchive {
+	return &branchDiffArchive{repo: repo, branch: branch}
+}
+
+type branchDiffArchive struct {
+	repo             *repo.Repo
+	branch           string
+	htmlURL          string
+	headSHA, treeSHA string
+}
+
+func (a *branchDiffArchive) Store(ctx context.Context, name, content string) (string, error) {
+	if a.htmlURL == "" {
+		if err := a.init(ctx); err != nil {
+			return "", err
+		}
+	}
+	// the content is sent as a blob, since the trees with the inline contents
+	// are limited in size, and the diffs are not always valid in UTF-8
+	blob, err := a.repo.CreateGitBlob(ctx, &github.CreateGitBlobParams{
+		Content: base64.StdEncoding.EncodeToString([]byte(content)), Encoding: "base64",
+	})
+	if err != nil {
+		return "", err
+	}
+	tree, err := a.repo.CreateGitTree(ctx, &github.CreateGitTreeParams{
+		BaseTree: a.treeSHA,
+		Tree: []*github.GitTreeEntry{
+			{Path: name, Mode: "100644", Type: "blob", SHA: blob.SHA},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	params := &github.CreateGitCommitParams{
+		Message: "Archive " + name, Tree: tree.SHA, Parents: []string{},
+	}
+	if a.headSHA != "" {
+		params.Parents = append(params.Parents, a.headSHA)
+	}
+	commit, err := a.repo.CreateGitCommit(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	if a.headSHA == "" {
+		_, err = a.repo.CreateGitRef(ctx, &github.CreateGitRefParams{
+			Ref: "refs/heads/" + a.branch, SHA: commit.SHA,
+		})
+	} else {
+		_, err = a.repo.UpdateGitRef(ctx, "heads/"+a.branch, &github.UpdateGitRefParams{
+			SHA: commit.SHA,
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+	a.headSHA, a.treeSHA = commit.SHA, tree.SHA
+	return fmt.Sprintf("%s/blob/%s/%s", a.htmlURL, a.branch, name), nil
+}
+
+func (a *branchDiffArchive) init(ctx context.Context) error {
+	r, err := a.repo.Get(ctx)
+	if err != nil {
+		return err
+	}
+	ref, err := a.repo.GetGitRef(ctx, "heads/"+a.branch)
+	if err != nil {
+		if strings.Contains(err.Error(), "Git Repository is empty") {
+			return fmt.Errorf("archiving the diffs to %s requires at least one commit: %w", r.FullName, err)
+		}
+		if !strings.Contains(err.Error(), "Not Found") {
+			return err
+		}
+	} else {
+		commit, err := a.repo.GetGitCommit(ctx, ref.Object.SHA)
+		if err != nil {
+			return err
+		}
+		a.headSHA, a.treeSHA = commit.SHA, commit.Tree.SHA
+	}
+	a.htmlURL = r.HTMLURL
+	return nil
+}
+
+type archivedDiff struct {
+	diffName, patchName string
+	diffURL, patchURL   string
+}
+
+// The diffs and patches are read up to this size to be archived, while the
+// diffs to be rendered are read up to 1 MiB.
+const maxArchivedDiffSize = 100 * 1024 * 1024
+
+// archiveDiff stores the full diff and patch of the pull request. The archived
+// files are memoized, so that the retries of the import do not store them again.
+func (m *migrator) archiveDiff(ctx context.Context, pullReq *github.PullReq, diff string) (*archivedDiff, error) {
+	if a, ok := m.archivedDiffs[pullReq.Number]; ok {
+		return a, nil
+	}
+	ctx = github.WithMaxDiffSize(ctx, maxArchivedDiffSize)
+	var fullDiff string
+	if missing, err := m.fetchPullReqDiff(ctx, pullReq, "diff", func(p DiffProvider) (err error) {
+		fullDiff, err = p.GetDiff(ctx, pullReq)
+		return
+	}); err != nil {
+		return nil, err
+	} else if missing == "" {
+		if err := m.scrubSecret(&fullDiff, pullReq.HTMLURL, "archived diff"); err != nil {
+			return nil, err
+		}
+		diff = fullDiff
+	}
+	var patch string
+	if _, err := m.fetchPullReqDiff(ctx, pullReq, "patch", func(p DiffProvider) (err error) {
+		patch, err = p.GetPatch(ctx, pullReq)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	if err := m.scrubSecret(&patch, pullReq.HTMLURL, "patch"); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("%s/pull/%d", m.sourceRepo.FullName, pullReq.Number)
+	fmt.Printf("[>>] archiving the diff: %s.diff\n", name)
+	diffURL, err := m.diffArchive.Store(ctx, name+".diff", diff)
+	if err != nil {
+		return nil, err
+	}
+	a := &archivedDiff{diffName: name + ".diff", diffURL: diffURL}
+	if patch != "" {
+		a.patchName = name + ".patch"
+		if a.patchURL, err = m.diffArchive.Store(ctx, a.patchName, patch); err != nil {
+			return nil, err
+		}
+	}
+	if m.archivedDiffs == nil {
+		m.archivedDiffs = make(map[int]*archivedDiff)
+	}
+	m.archivedDiffs[pullReq.Number] = a
+	return a, nil
+}
@@ -0,0 +1,165 @@
+package migrator
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestLocalDiffArchive(t *testing.T) {
+	dir := t.TempDir()
+	url, err := NewLocalDiffArchive(dir, "").Store(context.Background(), "example/source/pull/1.diff", "diff")
+	require.NoError(t, err)
+	assert.Equal(t, "", url)
+	bs, err := os.ReadFile(filepath.Join(dir, "example", "source", "pull", "1.diff"))
+	require.NoError(t, err)
+	assert.Equal(t, "diff", string(bs))
+
+	url, err = NewLocalDiffArchive(dir, "http://localhost/diffs/").Store(context.Background(), "example/source/pull/1.patch", "patch")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/diffs/example/source/pull/1.patch", url)
+}
+
+func TestBranchDiffArchive(t *testing.T) {
+	var refs []string
+	archive := NewBranchDiffArchive(repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{HTMLURL: "http://localhost/example/target"}, nil
+		}),
+		github.MockGetGitRef(func(string, string) (*github.GitRef, error) {
+			return nil, errors.New("GetGitRef example/target/git/ref/heads/archive: Not Found")
+		}),
+		github.MockCreateGitBlob(func(_ string, params *github.CreateGitBlobParams) (*github.GitBlob, error) {
+			assert.Equal(t, "base64", params.Encoding)
+			content, err := base64.StdEncoding.DecodeString(params.Content)
+			require.NoError(t, err)
+			return &github.GitBlob{SHA: "blob-" + string(content)}, nil
+		}),
+		github.MockCreateGitTree(func(_ string, params *github.CreateGitTreeParams) (*github.GitTree, error) {
+			assert.Equal(t, "blob", params.Tree[0].Type)
+			assert.Equal(t, "", params.Tree[0].Content)
+			return &github.GitTree{SHA: "tree-" + params.Tree[0].SHA}, nil
+		}),
+		github.MockCreateGitCommit(func(_ string, params *github.CreateGitCommitParams) (*github.GitCommit, error) {
+			return &github.GitCommit{SHA: "commit-" + params.Tree, Tree: github.GitTree{SHA: params.Tree}}, nil
+		}),
+		github.MockCreateGitRef(func(_ string, params *github.CreateGitRefParams) (*github.GitRef, error) {
+			refs = append(refs, params.Ref+" "+params.SHA)
+			return nil, nil
+		}),
+		github.MockUpdateGitRef(func(_ string, ref string, params *github.UpdateGitRefParams) (*github.GitRef, error) {
+			refs = append(refs, ref+" "+params.SHA)
+			return nil, nil
+		}),
+	), "example/target"), "archive")
+
+	url, err := archive.Store(context.Background(), "example/source/pull/1.diff", "diff")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/example/target/blob/archive/example/source/pull/1.diff", url)
+	url, err = archive.Store(context.Background(), "example/source/pull/1.patch", "patch")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/example/target/blob/archive/example/source/pull/1.patch", url)
+	assert.Equal(t, []string{
+		"refs/heads/archive commit-tree-blob-diff",
+		"heads/archive commit-tree-blob-patch",
+	}, refs)
+}
+
+func TestBranchDiffArchiveEmpty(t *testing.T) {
+	archive := NewBranchDiffArchive(repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}, nil
+		}),
+		github.MockGetGitRef(func(string, string) (*github.GitRef, error) {
+			return nil, errors.New("GetGitRef example/target/git/ref/heads/archive: Git Repository is empty.")
+		}),
+	), "example/target"), "archive")
+	_, err := archive.Store(context.Background(), "example/source/pull/1.diff", "diff")
+	assert.EqualError(t, err, "archiving the diffs to example/target requires at least one commit: "+
+		"GetGitRef example/target/git/ref/heads/archive: Git Repository is empty.")
+}
+
+func TestBuildDiffDetailsArchived(t *testing.T) {
+	b := &builder{
+		migrator:   &migrator{},
+		pullReq:    &github.PullReq{ChangedFiles: 1, Additions: 1},
+		commitDiff: "diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -0,0 +1 @@\n+x\n",
+		archivedDiff: &archivedDiff{
+			diffName: "example/source/pull/1.diff", patchName: "example/source/pull/1.patch",
+		},
+	}
+	assert.Contains(t, b.buildDiffDetails(), "The diff is truncated. The full diff is archived as "+
+		"<code>example/source/pull/1.diff</code> and the patch as <code>example/source/pull/1.patch</code>.\n")
+
+	b.archivedDiff.diffURL = "http://localhost/diffs/example/source/pull/1.diff"
+	b.archivedDiff.patchURL = "http://localhost/diffs/example/source/pull/1.patch"
+	assert.Contains(t, b.buildDiffDetails(), `The diff is truncated. See the full `+
+		`<a href="http://localhost/diffs/example/source/pull/1.diff">diff</a> and `+
+		`<a href="http://localhost/diffs/example/source/pull/1.patch">patch</a>.`+"\n")
+}
+
+type staticDiffProvider struct {
+	diff, patch string
+}
+
+func (p *staticDiffProvider) GetDiff(context.Context, *github.PullReq) (string, error) {
+	return p.diff, nil
+}
+
+func (p *staticDiffProvider) GetPatch(context.Context, *github.PullReq) (string, error) {
+	return p.patch, nil
+}
+
+func (p *staticDiffProvider) ListCommits(context.Context, *github.PullReq) ([]*github.Commit, error) {
+	return nil, nil
+}
+
+type countingDiffArchive struct {
+	names []string
+}
+
+func (a *countingDiffArchive) Store(_ context.Context, name, _ string) (string, error) {
+	a.names = append(a.names, name)
+	return "http://localhost/diffs/" + name, nil
+}
+
+func TestArchiveDiffMemoized(t *testing.T) {
+	archive := &countingDiffArchive{}
+	m := &migrator{sourceRepo: &github.Repo{FullName: "example/source"}}
+	ProvideDiffs(&staticDiffProvider{diff: "diff", patch: "patch"})(m)
+	ArchiveDiffs(archive)(m)
+	pullReq := &github.PullReq{Issue: github.Issue{Number: 1, HTMLURL: "http://localhost/example/source/pull/1"}}
+	for i := 0; i < 2; i++ {
+		a, err := m.archiveDiff(context.Background(), pullReq, "diff")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost/diffs/example/source/pull/1.diff", a.diffURL)
+		assert.Equal(t, "http://localhost/diffs/example/source/pull/1.patch", a.patchURL)
+	}
+	assert.Equal(t, []string{"example/source/pull/1.diff", "example/source/pull/1.patch"}, archive.names)
+}
+
+func TestBuildDiffDetailsMoreFiles(t *testing.T) {
+	var diff strings.Builder
+	for i := 0; i < maxTableRows+1; i++ {
+		fmt.Fprintf(&diff, "diff --git a/%[1]d b/%[1]d\n--- a/%[1]d\n+++ b/%[1]d\n@@ -0,0 +1 @@\n+x\n", i)
+	}
+	b := &builder{
+		migrator:   &migrator{},
+		pullReq:    &github.PullReq{ChangedFiles: maxTableRows + 1, Additions: maxTableRows + 1},
+		commitDiff: diff.String(),
+	}
+	details := b.buildDiffDetails()
+	assert.Contains(t, details, fmt.Sprintf("  <td colspan=\"3\">\n    more %d files\n  </td>\n", maxTableRows-truncatedTableRows))
+	assert.NotContains(t, details, fmt.Sprintf("<code>%d</code>", truncatedTableRows+1))
+}
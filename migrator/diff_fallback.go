@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// FallbackDiffs returns a migrator option to try the providers in order when
+// the diff, patch or commits of a pull request are not available (for example,
+// the head branch was deleted and the commits were garbage collected).
+func FallbackDiffs(providers ...DiffProvider) Option {
+	return func(m *migrator) {
+		m.diffFallbacks = append(m.diffFallbacks, providers...)
+	}
+}
+
+// SkipMissingDiffs returns a migrator option to import the pull requests
+// without the diffs (or the commits) when they are not available from any
+// provider. The pull requests are marked and recorded in the report.
+func SkipMissingDiffs() Option {
+	return func(m *migrator) {
+		m.skipMissingDiffs = true
+	}
+}
+
+// NewCommitDiffProvider creates a DiffProvider to build the diff from the diffs
+// of the commits of pull requests, instead of comparing the base and head.
+func NewCommitDiffProvider(repo *repo.Repo) DiffProvider {
+	return &commitDiffProvider{apiDiffProvider{repo: repo}}
+}
+
+type commitDiffProvider struct {
+	apiDiffProvider
+}
+
+func (p *commitDiffProvider) String() string {
+	return "diffs of the commits"
+}
+
+func (p *commitDiffProvider) GetDiff(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	commits, err := p.ListCommits(ctx, pullReq)
+	if err != nil {
+		return "", err
+	}
+	path := p.repo.NewPath(pullReq.Base.Repo.FullName)
+	var s strings.Builder
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			continue // skip merge commits
+		}
+		diff, err := path.GetDiff(ctx, c.SHA)
+		if err != nil {
+			return "", err
+		}
+		s.WriteString(diff)
+	}
+	return s.String(), nil
+}
+
+func (p *commitDiffProvider) GetPatch(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	return "", fmt.Errorf("patch of %s is not available from %s", pullReq.HTMLURL, p)
+}
+
+// The error messages of the API and git command when the commits are missing
+// (or the diff is not available from the provider).
+var missingDiffMessages = []string{
+	"Not Found",
+	"No common ancestor",
+	"not available from",
+	"unknown revision",
+	"Invalid revision range",
+	"Invalid symmetric difference expression",
+	"bad object",
+}
+
+func isMissingDiff(err error) bool {
+	for _, msg := range missingDiffMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchPullReqDiff calls the function with the providers until it succeeds.
+// Only the errors of the missing commits fall back to the next provider, and
+// the other errors (like the rate limit or server errors) are returned as is.
+// It returns the error message with no error when the missing diffs are skipped.
+func (m *migrator) fetchPullReqDiff(ctx context.Context,
+	pullReq *github.PullReq, location string, fetch func(DiffProvider) error,
+) (string, error) {
+	err := fetch(m.diffProvider)
+	if err == nil {
+		return "", nil
+	}
+	for _, p := range m.diffFallbacks {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !isMissingDiff(err) {
+			return "", err
+		}
+		fmt.Printf("[!!] failed to get the %s: %s (falling back to %s)\n", location, err, p)
+		if err := m.report(&reportEntry{
+			Type:     "diff_fallback",
+			URL:      pullReq.HTMLURL,
+			Location: location,
+			Message:  fmt.Sprintf("%s (fell back to %s)", err, p),
+		}); err != nil {
+			return "", err
+		}
+		if err = fetch(p); err == nil {
+			return "", nil
+		}
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if !m.skipMissingDiffs || !isMissingDiff(err) {
+		return "", err
+	}
+	fmt.Printf("[!!] failed to get the %s: %s (skipping)\n", location, err)
+	if err := m.report(&reportEntry{
+		Type:     "diff_missing",
+		URL:      pullReq.HTMLURL,
+		Location: location,
+		Message:  err.Error(),
+	}); err != nil {
+		return "", err
+	}
+	return err.Error(), nil
+}
@@ -0,0 +1,99 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestFetchPullReqDiff(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockListPullReqCommits(func(string, int) github.Commits {
+			var merge github.Commit
+			merge.SHA = "sha3"
+			merge.Parents = make([]struct {
+				URL string `json:"url"`
+				SHA string `json:"sha"`
+			}, 2)
+			return github.CommitsFromSlice([]*github.Commit{{SHA: "sha1"}, {SHA: "sha2"}, &merge})
+		}),
+		github.MockGetDiff(func(path string, sha string) (string, error) {
+			assert.Equal(t, "example/source", path)
+			return "diff of " + sha + "\n", nil
+		}),
+		github.MockGetCompare(func(string, string, string) (string, error) {
+			return "", errors.New("GetCompare example/source/compare/sha0...sha2: Not Found")
+		}),
+		github.MockGetComparePatch(func(string, string, string) (string, error) {
+			return "", errors.New("GetComparePatch example/source/compare/sha0...sha2: Not Found")
+		}),
+	), "example/source")
+	pullReq := &github.PullReq{
+		Issue: github.Issue{HTMLURL: "http://localhost/example/source/pull/1"},
+		Base:  &github.PullReqRef{SHA: "sha0", Repo: &github.Repo{FullName: "example/source"}},
+		Head:  &github.PullReqRef{SHA: "sha2"},
+	}
+
+	report := new(bytes.Buffer)
+	m := New(source, nil, nil,
+		FallbackDiffs(NewCommitDiffProvider(source)), SkipMissingDiffs(), Report(report),
+	).(*migrator)
+	var diff, patch string
+	missing, err := m.fetchPullReqDiff(context.Background(), pullReq, "diff", func(p DiffProvider) (err error) {
+		diff, err = p.GetDiff(context.Background(), pullReq)
+		return
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", missing)
+	assert.Equal(t, "diff of sha1\ndiff of sha2\n", diff)
+	missing, err = m.fetchPullReqDiff(context.Background(), pullReq, "patch", func(p DiffProvider) (err error) {
+		patch, err = p.GetPatch(context.Background(), pullReq)
+		return
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "patch of http://localhost/example/source/pull/1 is not available from diffs of the commits", missing)
+	assert.Equal(t, "", patch)
+	assert.Equal(t, `{"type":"diff_fallback","url":"http://localhost/example/source/pull/1","location":"diff","message":"GetCompare example/source/compare/sha0...sha2: Not Found (fell back to diffs of the commits)"}
+{"type":"diff_fallback","url":"http://localhost/example/source/pull/1","location":"patch","message":"GetComparePatch example/source/compare/sha0...sha2: Not Found (fell back to diffs of the commits)"}
+{"type":"diff_missing","url":"http://localhost/example/source/pull/1","location":"patch","message":"patch of http://localhost/example/source/pull/1 is not available from diffs of the commits"}
+`, report.String())
+
+	m = New(source, nil, nil).(*migrator)
+	_, err = m.fetchPullReqDiff(context.Background(), pullReq, "diff", func(p DiffProvider) (err error) {
+		diff, err = p.GetDiff(context.Background(), pullReq)
+		return
+	})
+	assert.EqualError(t, err, "GetCompare example/source/compare/sha0...sha2: Not Found")
+}
+
+func TestFetchPullReqDiffError(t *testing.T) {
+	report := new(bytes.Buffer)
+	m := New(nil, nil, nil,
+		FallbackDiffs(NewCommitDiffProvider(nil)), SkipMissingDiffs(), Report(report),
+	).(*migrator)
+	pullReq := &github.PullReq{Issue: github.Issue{HTMLURL: "http://localhost/example/source/pull/1"}}
+	var calls int
+	_, err := m.fetchPullReqDiff(context.Background(), pullReq, "diff", func(DiffProvider) error {
+		calls++
+		return errors.New("GetCompare example/source/compare/sha0...sha2: 502 Bad Gateway")
+	})
+	assert.EqualError(t, err, "GetCompare example/source/compare/sha0...sha2: 502 Bad Gateway")
+	assert.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = m.fetchPullReqDiff(ctx, pullReq, "diff", func(DiffProvider) error {
+		calls++
+		cancel()
+		return errors.New("GetCompare example/source/compare/sha0...sha2: Not Found")
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "", report.String())
+}
@@ -0,0 +1,141 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// DiffProvider represents a provider of the diffs and commits of pull requests.
+type DiffProvider interface {
+	// GetDiff returns the diff between the base and head of the pull request.
+	GetDiff(ctx context.Context, pullReq *github.PullReq) (string, error)
+	// GetPatch returns the patch of the commits of the pull request.
+	GetPatch(ctx context.Context, pullReq *github.PullReq) (string, error)
+	// ListCommits returns the commits of the pull request.
+	ListCommits(ctx context.Context, pullReq *github.PullReq) ([]*github.Commit, error)
+}
+
+// ProvideDiffs returns a migrator option to get the diffs and commits of the
+// pull requests from the provider, instead of the API of the source.
+func ProvideDiffs(provider DiffProvider) Option {
+	return func(m *migrator) {
+		m.diffProvider = provider
+	}
+}
+
+type apiDiffProvider struct {
+	repo *repo.Repo
+}
+
+func (p *apiDiffProvider) String() string {
+	return "API of the source"
+}
+
+func (p *apiDiffProvider) GetDiff(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	return p.repo.NewPath(pullReq.Base.Repo.FullName).
+		GetCompare(ctx, pullReq.Base.SHA, pullReq.Head.SHA)
+}
+
+func (p *apiDiffProvider) GetPatch(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	return p.repo.NewPath(pullReq.Base.Repo.FullName).
+		GetComparePatch(ctx, pullReq.Base.SHA, pullReq.Head.SHA)
+}
+
+func (p *apiDiffProvider) ListCommits(ctx context.Context, pullReq *github.PullReq) ([]*github.Commit, error) {
+	return github.CommitsToSlice(p.repo.ListPullReqCommits(ctx, pullReq.Number))
+}
+
+// NewGitDiffProvider creates a DiffProvider to compute the diffs and commits
+// with git command in the local clone (a bare clone with the --mirror flag is
+// recommended since it contains the heads of the pull requests).
+func NewGitDiffProvider(gitDir string) DiffProvider {
+	return &gitDiffProvider{gitDir: gitDir}
+}
+
+type gitDiffProvider struct {
+	gitDir string
+}
+
+func (p *gitDiffProvider) String() string {
+	return "git clone at " + p.gitDir
+}
+
+func (p *gitDiffProvider) GetDiff(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	return p.git(ctx,
+		"diff", "--no-color", "--no-ext-diff", "--find-renames",
+		"--src-prefix=a/", "--dst-prefix=b/",
+		pullReq.Base.SHA+"..."+pullReq.Head.SHA,
+	)
+}
+
+func (p *gitDiffProvider) GetPatch(ctx context.Context, pullReq *github.PullReq) (string, error) {
+	return p.git(ctx,
+		"format-patch", "--stdout", "--no-color", "--find-renames",
+		pullReq.Base.SHA+".."+pullReq.Head.SHA,
+	)
+}
+
+// The fields are separated by unit separators, and the commits are separated
+// by null characters (with -z flag).
+const gitLogFormat = "--format=%H%x1f%P%x1f%an%x1f%ae%x1f%aI%x1f%cn%x1f%ce%x1f%cI%x1f%B"
+
+func (p *gitDiffProvider) ListCommits(ctx context.Context, pullReq *github.PullReq) ([]*github.Commit, error) {
+	out, err := p.git(ctx,
+		"log", "-z", "--reverse", "--topo-order", gitLogFormat,
+		pullReq.Base.SHA+".."+pullReq.Head.SHA,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var commits []*github.Commit
+	for _, s := range strings.Split(out, "\x00") {
+		xs := strings.Split(s, "\x1f")
+		if len(xs) != 9 {
+			continue
+		}
+		c := &github.Commit{SHA: xs[0]}
+		if pullReq.Base.Repo != nil {
+			c.HTMLURL = pullReq.Base.Repo.HTMLURL + "/commit/" + xs[0]
+		}
+		c.Commit.Author = &github.CommitUser{Name: xs[2], Email: xs[3], Date: formatGitDate(xs[4])}
+		c.Commit.Committer = &github.CommitUser{Name: xs[5], Email: xs[6], Date: formatGitDate(xs[7])}
+		c.Commit.Message = strings.TrimRight(xs[8], "\n")
+		for _, sha := range strings.Fields(xs[1]) {
+			c.Parents = append(c.Parents, struct {
+				URL string `json:"url"`
+				SHA string `json:"sha"`
+			}{SHA: sha})
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// formatGitDate formats the date in UTC as the API does.
+func formatGitDate(date string) string {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (p *gitDiffProvider) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", p.gitDir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestGitDiffProvider(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=sample-user-1", "GIT_AUTHOR_EMAIL=user1@example.com",
+			"GIT_AUTHOR_DATE=2019-11-10T12:00:00Z",
+			"GIT_COMMITTER_NAME=sample-user-2", "GIT_COMMITTER_EMAIL=user2@example.com",
+			"GIT_COMMITTER_DATE=2019-11-10T13:00:00Z",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	git("init", "--quiet")
+	write("README.md", "# README\ndeleted\n")
+	git("add", "README.md")
+	git("commit", "--quiet", "--message", "initial commit")
+	base := git("rev-parse", "HEAD")
+	write("README.md", "# README\nadded\n")
+	git("commit", "--quiet", "--all", "--message", "commit message 1\n\ndetails")
+	write("CHANGELOG.md", "# CHANGELOG\n")
+	git("add", "CHANGELOG.md")
+	git("commit", "--quiet", "--message", "commit message 2")
+	head := git("rev-parse", "HEAD")
+
+	provider := NewGitDiffProvider(filepath.Join(dir, ".git"))
+	pullReq := &github.PullReq{
+		Base: &github.PullReqRef{SHA: base, Repo: &github.Repo{HTMLURL: "http://localhost/example/source"}},
+		Head: &github.PullReqRef{SHA: head},
+	}
+
+	diff, err := provider.GetDiff(context.Background(), pullReq)
+	require.NoError(t, err)
+	files := parseDiff(diff)
+	require.Len(t, files, 2)
+	assert.Equal(t, "CHANGELOG.md", files[0].path)
+	assert.Equal(t, diffFileStatusAdded, files[0].status)
+	assert.Equal(t, "README.md", files[1].path)
+	assert.Equal(t, diffFileStatusModified, files[1].status)
+	assert.Equal(t, 1, files[1].additions)
+	assert.Equal(t, 1, files[1].deletions)
+
+	patch, err := provider.GetPatch(context.Background(), pullReq)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(patch, "\nSubject: [PATCH "))
+
+	commits, err := provider.ListCommits(context.Background(), pullReq)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "commit message 1\n\ndetails", commits[0].Commit.Message)
+	assert.Equal(t, "commit message 2", commits[1].Commit.Message)
+	assert.Equal(t, head, commits[1].SHA)
+	assert.Equal(t, "http://localhost/example/source/commit/"+head, commits[1].HTMLURL)
+	assert.Equal(t, "sample-user-1", commits[1].Commit.Author.Name)
+	assert.Equal(t, "sample-user-2", commits[1].Commit.Committer.Name)
+	assert.Equal(t, "2019-11-10T13:00:00Z", commits[1].Commit.Committer.Date)
+	assert.Len(t, commits[1].Parents, 1)
+	assert.Nil(t, commits[1].Committer)
+	assert.Nil(t, commits[1].Author)
+
+	b := &builder{migrator: &migrator{}, pullReq: &github.PullReq{Commits: 2}, commits: commits, localCommits: true}
+	details := b.buildCommitDetails()
+	assert.Contains(t, details, "<br>\nsample-user-2 committed on Sun 10, 2019")
+	assert.NotContains(t, details, "@sample-user-2")
+	assert.NotContains(t, details, "<img")
+
+	_, err = provider.GetDiff(context.Background(), &github.PullReq{
+		Base: &github.PullReqRef{SHA: base}, Head: &github.PullReqRef{SHA: strings.Repeat("0", 40)},
+	})
+	assert.Error(t, err)
+}
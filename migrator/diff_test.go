@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTruncateDiff(t *testing.T) {
@@ -57,7 +58,9 @@ index 1234567..89abcde 100644
 ` + strings.Repeat("\n", 20000),
 			expected: `diff --git a/README.md b/README.md
 index 1234567..89abcde 100644
-Too large diff
+--- a/README.md
++++ b/README.md
+Too large diff of README.md
 `,
 		},
 		{
@@ -80,7 +83,9 @@ index 1234567..89abcde 100644
 `,
 			expected: `diff --git a/README.md b/README.md
 index 1234567..89abcde 100644
-Too large diff
+--- a/README.md
++++ b/README.md
+Too large diff of README.md
 diff --git a/CHANGELOG.md b/CHANGELOG.md
 index 1234567..89abcde 100644
 --- a/CHANGELOG.md
@@ -109,10 +114,116 @@ index 1234567..89abcde 100644
 # README
 `+strings.Repeat("\n", 5000)+`
 +added
-`, 20)[:60000] + "\n\nToo large diff\n",
+`, 11) + strings.Repeat(`diff --git a/README.md b/README.md
+index 1234567..89abcde 100644
+--- a/README.md
++++ b/README.md
+Too large diff of README.md
+`, 9),
 		},
 	}
 	for _, tc := range testCases {
 		assert.Equal(t, tc.expected, truncateDiff(tc.src))
 	}
 }
+
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/README.md b/README.md
+index 1234567..89abcde 100644
+--- a/README.md
++++ b/README.md
+@@ -1,6 +1,16 @@
+# README
+-deleted
++added
++added
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..89abcde
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++added
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 1234567..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-deleted
+diff --git a/before.txt b/after.txt
+similarity index 100%
+rename from before.txt
+rename to after.txt
+diff --git a/image.png b/image.png
+index 1234567..89abcde 100644
+Binary files a/image.png and b/image.png differ
+`
+	files := parseDiff(diff)
+	require.Len(t, files, 5)
+	var s strings.Builder
+	for _, f := range files {
+		s.WriteString(f.String())
+	}
+	assert.Equal(t, diff, s.String())
+	testCases := []struct {
+		path, oldPath        string
+		status               diffFileStatus
+		additions, deletions int
+	}{
+		{"README.md", "", diffFileStatusModified, 2, 1},
+		{"new.txt", "", diffFileStatusAdded, 1, 0},
+		{"old.txt", "", diffFileStatusDeleted, 0, 1},
+		{"after.txt", "before.txt", diffFileStatusRenamed, 0, 0},
+		{"image.png", "", diffFileStatusBinary, 0, 0},
+	}
+	for i, tc := range testCases {
+		assert.Equal(t, tc.path, files[i].path)
+		assert.Equal(t, tc.oldPath, files[i].oldPath)
+		assert.Equal(t, tc.status, files[i].status)
+		assert.Equal(t, tc.additions, files[i].additions)
+		assert.Equal(t, tc.deletions, files[i].deletions)
+	}
+}
+
+func TestMergeDiffFiles(t *testing.T) {
+	files := mergeDiffFiles(parseDiff(`diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..1234567
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++added
+diff --git a/README.md b/README.md
+index 1234567..89abcde 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-deleted
++added
+diff --git a/new.txt b/new.txt
+index 1234567..89abcde 100644
+--- a/new.txt
++++ b/new.txt
+@@ -1 +1,2 @@
+ added
++added
+diff --git a/README.md b/README.md
+deleted file mode 100644
+index 89abcde..0000000
+--- a/README.md
++++ /dev/null
+@@ -1 +0,0 @@
+-added
+`))
+	require.Len(t, files, 2)
+	assert.Equal(t, "new.txt", files[0].path)
+	assert.Equal(t, diffFileStatusAdded, files[0].status)
+	assert.Equal(t, 2, files[0].additions)
+	assert.Equal(t, 0, files[0].deletions)
+	assert.Equal(t, "README.md", files[1].path)
+	assert.Equal(t, diffFileStatusDeleted, files[1].status)
+	assert.Equal(t, 1, files[1].additions)
+	assert.Equal(t, 2, files[1].deletions)
+	assert.Equal(t, 2, strings.Count(files[1].String(), "diff --git "))
+}
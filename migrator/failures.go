@@ -0,0 +1,231 @@
+package migrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// ErrorPolicy represents the policy on the failures of migrating issues.
+type ErrorPolicy int
+
+// ErrorPolicy ...
+const (
+	// ErrorPolicyAbort aborts the migration (this is the default).
+	ErrorPolicyAbort ErrorPolicy = iota
+	// ErrorPolicyPlaceholder creates a placeholder issue to keep the issue
+	// numbers aligned and continues the migration.
+	ErrorPolicyPlaceholder
+)
+
+// IssueErrorPolicy returns a migrator option to configure the policy on the
+// failures of migrating issues. The failures are written to the queue in JSON
+// lines, which can be read by ReadFailures to retry them.
+func IssueErrorPolicy(policy ErrorPolicy, queue io.Writer) Option {
+	return func(m *migrator) {
+		m.errorPolicy = policy
+		if queue != nil {
+			m.failureEncoder = json.NewEncoder(queue)
+		}
+	}
+}
+
+// Failure represents a failure of migrating an issue.
+type Failure struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Error  string `json:"error"`
+}
+
+// ReadFailures reads the failures written in JSON lines.
+func ReadFailures(r io.Reader) ([]*Failure, error) {
+	var failures []*Failure
+	dec := json.NewDecoder(r)
+	for {
+		var f Failure
+		if err := dec.Decode(&f); err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			return failures, nil
+		}
+		failures = append(failures, &f)
+	}
+}
+
+func (m *migrator) writeFailure(issue *github.Issue, err error) error {
+	return m.encodeFailure(&Failure{
+		Number: issue.Number, URL: issue.HTMLURL, Error: err.Error(),
+	})
+}
+
+func (m *migrator) encodeFailure(f *Failure) error {
+	if m.failureEncoder == nil {
+		return nil
+	}
+	return m.failureEncoder.Encode(f)
+}
+
+const placeholderIssueTitle = "[Failed issue]"
+
+func (m *migrator) migratePlaceholderIssue(ctx context.Context, sourceIssue *github.Issue, cause error) error {
+	fmt.Printf("[!!] migrating %s failed: %s\n", sourceIssue.HTMLURL, cause)
+	if err := m.writeFailure(sourceIssue, cause); err != nil {
+		return err
+	}
+	fmt.Printf("[>>] creating a placeholder issue: (original: %s)\n", sourceIssue.HTMLURL)
+	result, err := m.target.Import(withoutCancel(ctx), &github.Import{
+		Issue: &github.ImportIssue{
+			Title: placeholderIssueTitle,
+			Body: m.newIssueProvenance(sourceIssue).embed(fmt.Sprintf(`<table>
+<tr>
+  <td>This issue failed to be imported from %s, and will be replaced on retrying.</td>
+</tr>
+</table>
+`, buildIssueLinkTag(m.sourceRepo, sourceIssue)), 0),
+			CreatedAt: sourceIssue.CreatedAt,
+			UpdatedAt: sourceIssue.UpdatedAt,
+			Closed:    true,
+			ClosedAt:  sourceIssue.ClosedAt,
+		},
+		Comments: []*github.ImportComment{},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := m.waitImportIssue(ctx, result.ID, sourceIssue); err != nil {
+		return fmt.Errorf("importing a placeholder of %s failed: %w", sourceIssue.HTMLURL, err)
+	}
+	return nil
+}
+
+// RetryFailed retries migrating the failed issues, and replaces the
+// placeholder issues. Note that the created dates of the comments are lost
+// since the comments are created via the API.
+func (m *migrator) RetryFailed(ctx context.Context, failures []*Failure) (err error) {
+	defer func() {
+		// the remaining failures are written back to the queue (for example,
+		// on the graceful shutdown) to retry them later
+		if err != nil {
+			for _, f := range failures {
+				if e := m.encodeFailure(f); e != nil {
+					err = e
+				}
+			}
+		}
+	}()
+	if err = m.init(ctx); err != nil {
+		return err
+	}
+	if err = m.cacheTargetProjects(ctx); err != nil {
+		return err
+	}
+	if err = m.cacheMilestones(ctx); err != nil {
+		return err
+	}
+	var count int
+	for len(failures) > 0 {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		// the failure is taken out of the remaining ones before retrying,
+		// so that it is written to the queue at most once
+		f := failures[0]
+		failures = failures[1:]
+		if err := m.retryFailedIssue(ctx, f); err != nil {
+			fmt.Printf("[!!] retrying %s failed: %s\n", f.URL, err)
+			if err := m.writeFailure(&github.Issue{Number: f.Number, HTMLURL: f.URL}, err); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("retrying failed: %s", plural(count, "issue"))
+	}
+	return nil
+}
+
+func (m *migrator) retryFailedIssue(ctx context.Context, f *Failure) error {
+	fmt.Printf("[=>] retrying an issue: %s\n", f.URL)
+	targetIssue, err := m.target.GetIssue(ctx, f.Number)
+	if err != nil {
+		return err
+	}
+	if targetIssue.Title != placeholderIssueTitle {
+		fmt.Printf("[--] skipping: %s (not a placeholder)\n", targetIssue.HTMLURL)
+		return nil
+	}
+	sourceIssue, err := m.source.GetIssue(ctx, f.Number)
+	if err != nil {
+		return err
+	}
+	if err := m.checkIssueCollision(targetIssue, sourceIssue); err != nil {
+		return err
+	}
+	imp, err := m.buildIssueImport(ctx, sourceIssue, 0)
+	if err != nil {
+		return err
+	}
+	params := &github.UpdateIssueParams{
+		Title:     imp.Issue.Title,
+		Body:      imp.Issue.Body,
+		State:     github.IssueStateOpen,
+		Labels:    imp.Issue.Labels,
+		Milestone: imp.Issue.Milestone,
+	}
+	if imp.Issue.Closed {
+		params.State = github.IssueStateClosed
+	}
+	if imp.Issue.Assignee != "" {
+		params.Assignees = []string{imp.Issue.Assignee}
+	}
+	m.setImportedAssignee(sourceIssue, imp.Issue.Assignee)
+	created, err := m.listCreatedComments(ctx, f.Number)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[|>] replacing the placeholder issue: %s\n", targetIssue.HTMLURL)
+	// the title is updated at last so that the failure can be retried again,
+	// and the comments created on the previous retries are skipped
+	for _, c := range imp.Comments {
+		if p, _, ok := parseProvenance(c.Body); ok && created[newCommentKey(p)] {
+			continue
+		}
+		if _, err := m.target.CreateComment(ctx, f.Number, c.Body); err != nil {
+			return err
+		}
+	}
+	if _, err = m.target.UpdateIssue(ctx, f.Number, params); err != nil {
+		return err
+	}
+	return m.reconcileIssue(ctx, sourceIssue)
+}
+
+// commentKey identifies the part of the source comment (or the group of the
+// events) by the provenance metadata, regardless of the content.
+type commentKey struct {
+	sourceType, sourceURL, createdAt string
+	part                             int
+}
+
+func newCommentKey(p *provenance) commentKey {
+	return commentKey{p.SourceType, p.SourceURL, p.CreatedAt, p.Part}
+}
+
+func (m *migrator) listCreatedComments(ctx context.Context, issueNumber int) (map[commentKey]bool, error) {
+	comments, err := github.CommentsToSlice(m.target.ListComments(ctx, issueNumber))
+	if err != nil {
+		return nil, err
+	}
+	created := make(map[commentKey]bool, len(comments))
+	for _, c := range comments {
+		if p, _, ok := parseProvenance(c.Body); ok {
+			created[newCommentKey(p)] = true
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,150 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestMigratePlaceholderIssueAndRetry(t *testing.T) {
+	sourceIssue := &github.Issue{
+		Number:    2,
+		Title:     "Example title 2",
+		State:     github.IssueStateOpen,
+		Body:      "Example body 2",
+		HTMLURL:   "http://localhost/example/source/issues/2",
+		User:      &github.User{Login: "sample-user-1"},
+		CreatedAt: "2019-11-10T12:00:00Z",
+		Labels:    []*github.Label{{Name: "bug"}},
+	}
+	var imports []*github.Import
+	var comments []string
+	var params *github.UpdateIssueParams
+	updateErr := errors.New("Server Error")
+	source := repo.New(github.NewMockClient(
+		github.MockGetIssue(func(_ string, issueNumber int) (*github.Issue, error) {
+			assert.Equal(t, 2, issueNumber)
+			return sourceIssue, nil
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice([]*github.Comment{
+				{Body: "Example comment", User: &github.User{Login: "sample-user-2"}},
+			})
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+		github.MockListTimeline(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+	), "example/source")
+	target := repo.New(github.NewMockClient(
+		github.MockImport(func(_ string, imp *github.Import) (*github.ImportResult, error) {
+			imports = append(imports, imp)
+			return &github.ImportResult{ID: 12345, Status: "pending"}, nil
+		}),
+		github.MockGetImport(func(string, int) (*github.ImportResult, error) {
+			return &github.ImportResult{ID: 12345, Status: "imported"}, nil
+		}),
+		github.MockGetIssue(func(_ string, issueNumber int) (*github.Issue, error) {
+			require.Len(t, imports, 1)
+			return &github.Issue{
+				Number: issueNumber, Title: placeholderIssueTitle, Body: imports[0].Issue.Body,
+				HTMLURL: "http://localhost/example/target/issues/2",
+			}, nil
+		}),
+		github.MockListComments(func(_ string, issueNumber int) github.Comments {
+			assert.Equal(t, 2, issueNumber)
+			cs := make([]*github.Comment, len(comments))
+			for i, body := range comments {
+				cs[i] = &github.Comment{Body: body}
+			}
+			return github.CommentsFromSlice(cs)
+		}),
+		github.MockCreateComment(func(_ string, issueNumber int, body string) (*github.Comment, error) {
+			assert.Equal(t, 2, issueNumber)
+			comments = append(comments, body)
+			return &github.Comment{}, nil
+		}),
+		github.MockUpdateIssue(func(_ string, issueNumber int, p *github.UpdateIssueParams) (*github.Issue, error) {
+			assert.Equal(t, 2, issueNumber)
+			if updateErr != nil {
+				return nil, updateErr
+			}
+			params = p
+			return &github.Issue{}, nil
+		}),
+		github.MockGetUser(func(string) (*github.User, error) {
+			return nil, errors.New("Not Found")
+		}),
+	), "example/target")
+
+	queue := new(bytes.Buffer)
+	m := New(source, target, nil, IssueErrorPolicy(ErrorPolicyPlaceholder, queue)).(*migrator)
+	m.sourceRepo = &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	require.NoError(t, m.migratePlaceholderIssue(context.Background(), sourceIssue, errors.New("failed status")))
+	require.Len(t, imports, 1)
+	assert.Equal(t, placeholderIssueTitle, imports[0].Issue.Title)
+	assert.Contains(t, imports[0].Issue.Body, "http://localhost/example/source/issues/2")
+
+	failures, err := ReadFailures(queue)
+	require.NoError(t, err)
+	assert.Equal(t, []*Failure{
+		{Number: 2, URL: "http://localhost/example/source/issues/2", Error: "failed status"},
+	}, failures)
+
+	// the comments created on the failed retry are not duplicated
+	assert.Equal(t, updateErr, m.retryFailedIssue(context.Background(), failures[0]))
+	require.Len(t, comments, 1)
+	updateErr = nil
+	require.NoError(t, m.retryFailedIssue(context.Background(), failures[0]))
+	require.Len(t, comments, 1)
+	assert.Contains(t, comments[0], "Example comment")
+	require.NotNil(t, params)
+	assert.Equal(t, "Example title 2", params.Title)
+	assert.Contains(t, params.Body, "Example body 2")
+	assert.Equal(t, github.IssueStateOpen, params.State)
+	assert.Equal(t, []string{"bug"}, params.Labels)
+	assert.Equal(t, "", queue.String())
+}
+
+func TestRetryFailedCanceled(t *testing.T) {
+	client := github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/test", HTMLURL: "http://localhost/example/test"}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice(nil)
+		}),
+		github.MockListProjects(func(string, *github.ListProjectsParams) github.Projects {
+			return github.ProjectsFromSlice(nil)
+		}),
+		github.MockListMilestones(func(string, *github.ListMilestonesParams) github.Milestones {
+			return github.MilestonesFromSlice(nil)
+		}),
+	)
+	queue := new(bytes.Buffer)
+	m := New(
+		repo.New(client, "example/source"), repo.New(client, "example/target"), nil,
+		IssueErrorPolicy(ErrorPolicyPlaceholder, queue),
+	)
+	failures := []*Failure{
+		{Number: 2, URL: "http://localhost/example/source/issues/2", Error: "failed status"},
+		{Number: 5, URL: "http://localhost/example/source/issues/5", Error: "failed status"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.RetryFailed(ctx, failures)
+	assert.True(t, errors.Is(err, context.Canceled))
+	got, err := ReadFailures(queue)
+	require.NoError(t, err)
+	assert.Equal(t, failures, got)
+}
@@ -1,18 +1,19 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-func (m *migrator) migrateHooks() error {
-	sourceHooks, err := github.HooksToSlice(m.source.ListHooks())
+func (m *migrator) migrateHooks(ctx context.Context) error {
+	sourceHooks, err := github.HooksToSlice(m.source.ListHooks(ctx))
 	if err != nil {
 		return err
 	}
-	targetHooks, err := github.HooksToSlice(m.target.ListHooks())
+	targetHooks, err := github.HooksToSlice(m.target.ListHooks(ctx))
 	if err != nil {
 		return err
 	}
@@ -26,7 +27,7 @@ func (m *migrator) migrateHooks() error {
 					!reflect.DeepEqual(sourceHook.Events, targetHook.Events) ||
 					!reflect.DeepEqual(sourceHook.Config, targetHook.Config) {
 					fmt.Printf("[|>] updating an existing hook: %s\n", targetHook.Config.URL)
-					if _, err := m.target.UpdateHook(targetHook.ID, &github.UpdateHookParams{
+					if _, err := m.target.UpdateHook(ctx, targetHook.ID, &github.UpdateHookParams{
 						Active: sourceHook.Active,
 						Events: sourceHook.Events,
 						Config: sourceHook.Config,
@@ -44,7 +45,7 @@ func (m *migrator) migrateHooks() error {
 			continue
 		}
 		fmt.Printf("[>>] creating a new hook: %s\n", sourceHook.Config.URL)
-		if _, err := m.target.CreateHook(&github.CreateHookParams{
+		if _, err := m.target.CreateHook(ctx, &github.CreateHookParams{
 			Active: sourceHook.Active,
 			Events: sourceHook.Events,
 			Config: sourceHook.Config,
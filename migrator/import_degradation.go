@@ -0,0 +1,121 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// importDegradation represents the degradations applied to the importing
+// object to recover the import failures.
+type importDegradation int
+
+const (
+	degradeAssignee importDegradation = 1 << iota
+	degradeMilestone
+	degradeLabels
+	degradeBody
+	degradeComments
+)
+
+var importDegradationMessages = []struct {
+	degradation importDegradation
+	message     string
+}{
+	{degradeAssignee, "dropped the assignee"},
+	{degradeMilestone, "dropped the milestone"},
+	{degradeLabels, "trimmed the labels"},
+	{degradeBody, "truncated the body"},
+	{degradeComments, "split the comments"},
+}
+
+const maxLabelLength = 50
+
+// The body is truncated and the comments are split below this length on
+// recovering the failures, since the import API may reject the bodies under
+// maxBodyLength (for example, the length in characters after rendering).
+const degradedBodyLength = maxBodyLength / 2
+
+// bodyLength returns the length to split the body and comments.
+func (d importDegradation) bodyLength() int {
+	if d&degradeComments != 0 {
+		return degradedBodyLength
+	}
+	return maxBodyLength
+}
+
+// importDegradationOf inspects the errors of the API to determine the
+// degradations to recover the failure.
+func importDegradationOf(err error) importDegradation {
+	var es github.APIErrors
+	if !errors.As(err, &es) {
+		return 0
+	}
+	var d importDegradation
+	for _, e := range es {
+		switch {
+		case e.Resource == "Issue" && e.Field == "assignee":
+			d |= degradeAssignee
+		case e.Resource == "Issue" && e.Field == "milestone":
+			d |= degradeMilestone
+		case e.Resource == "Label" || e.Field == "labels":
+			d |= degradeLabels
+		case e.Resource == "Issue" && e.Field == "body":
+			d |= degradeBody
+		case strings.HasSuffix(e.Resource, "Comment") || strings.HasPrefix(e.Location, "/comments"):
+			d |= degradeComments
+		}
+	}
+	return d
+}
+
+func (m *migrator) reportDegradation(issue *github.Issue, d importDegradation, cause error) error {
+	for _, x := range importDegradationMessages {
+		if d&x.degradation == 0 {
+			continue
+		}
+		fmt.Printf("[!!] %s to recover the failure: %s\n", x.message, issue.HTMLURL)
+		if err := m.report(&reportEntry{
+			Type:    "import_degraded",
+			URL:     issue.HTMLURL,
+			Message: fmt.Sprintf("%s (%s)", x.message, cause),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// degradeImport applies the degradations to the import before splitting it
+// (the comments are split by splitImport with d.bodyLength()).
+func (m *migrator) degradeImport(imp *github.Import, ps *importProvenances,
+	sourceIssue *github.Issue, d importDegradation,
+) *github.Import {
+	if d&degradeAssignee != 0 {
+		imp.Issue.Assignee = ""
+	}
+	if d&degradeMilestone != 0 {
+		imp.Issue.Milestone = 0
+	}
+	if d&degradeLabels != 0 {
+		labels := make([]string, 0, len(imp.Issue.Labels))
+		seen := make(map[string]bool, len(imp.Issue.Labels))
+		for _, l := range imp.Issue.Labels {
+			l = strings.TrimSpace(truncateString(l, maxLabelLength))
+			if l != "" && !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+		imp.Issue.Labels = labels
+	}
+	if d&degradeBody != 0 {
+		suffix := fmt.Sprintf("\n\n(The body is truncated. See %s for the original body.)\n",
+			buildIssueLinkTag(m.sourceRepo, sourceIssue))
+		imp.Issue.Body = truncateString(imp.Issue.Body,
+			ps.issueProvenance().bodyLength(degradedBodyLength)-len(suffix)) + suffix
+	}
+	return imp
+}
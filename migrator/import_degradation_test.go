@@ -0,0 +1,88 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestImportDegradationOf(t *testing.T) {
+	err := fmt.Errorf("importing %s failed: %w", "http://localhost/example/source/issues/1",
+		fmt.Errorf("failed status: %w", github.APIErrors{
+			{Resource: "Issue", Field: "assignee", Code: "invalid", Value: "sample-user-1"},
+			{Resource: "Issue", Field: "milestone", Code: "invalid"},
+			{Resource: "Label", Field: "name", Code: "too_long"},
+			{Resource: "IssueComment", Field: "body", Code: "too_long", Location: "/comments[0]/body"},
+		}))
+	assert.Equal(t, degradeAssignee|degradeMilestone|degradeLabels|degradeComments, importDegradationOf(err))
+	assert.Equal(t, degradeBody, importDegradationOf(github.APIErrors{
+		{Resource: "Issue", Field: "body", Code: "too_long"},
+	}))
+	assert.Equal(t, importDegradation(0), importDegradationOf(errors.New("Not Found")))
+}
+
+func TestDegradeImport(t *testing.T) {
+	m := &migrator{sourceRepo: &github.Repo{FullName: "example/source"}}
+	imp := &github.Import{
+		Issue: &github.ImportIssue{
+			Body:      strings.Repeat("x", maxBodyLength+1),
+			Assignee:  "sample-user-1",
+			Milestone: 1,
+			Labels:    []string{strings.Repeat("a", 60), strings.Repeat("a", 50), "bug"},
+		},
+		Comments: []*github.ImportComment{
+			{Body: strings.Repeat("x\n", maxBodyLength/2+1), CreatedAt: "2019-11-10T12:00:00Z"},
+		},
+	}
+	d := degradeAssignee | degradeMilestone | degradeLabels | degradeBody | degradeComments
+	imp = splitImport(m.degradeImport(imp, nil, &github.Issue{
+		Number: 1, HTMLURL: "http://localhost/example/source/issues/1",
+	}, d), nil, d.bodyLength())
+	assert.Equal(t, "", imp.Issue.Assignee)
+	assert.Equal(t, 0, imp.Issue.Milestone)
+	assert.Equal(t, []string{strings.Repeat("a", 50), "bug"}, imp.Issue.Labels)
+	assert.Len(t, imp.Issue.Body, degradedBodyLength)
+	assert.True(t, strings.HasSuffix(imp.Issue.Body,
+		`(The body is truncated. See <a href="http://localhost/example/source/issues/1">example/source#1</a> for the original body.)`+"\n"))
+	assert.Len(t, imp.Comments, 3)
+	for _, c := range imp.Comments {
+		assert.LessOrEqual(t, len(c.Body), degradedBodyLength)
+		assert.Equal(t, "2019-11-10T12:00:00Z", c.CreatedAt)
+	}
+	var s strings.Builder
+	for i, c := range imp.Comments {
+		if i > 0 {
+			c.Body = strings.TrimPrefix(c.Body, continuedMarker)
+		}
+		s.WriteString(c.Body)
+	}
+	assert.Equal(t, strings.Repeat("x\n", maxBodyLength/2+1), s.String())
+}
+
+func TestDegradedImportProvenance(t *testing.T) {
+	q := &importQueue{fail: func(imp *github.Import) github.APIErrors {
+		if !strings.Contains(imp.Issue.Body, "(The body is truncated.") {
+			return github.APIErrors{{Resource: "Issue", Field: "body", Code: "invalid"}}
+		}
+		return nil
+	}}
+	m := newImportPipelineTestMigrator(q)
+	p := m.newImportPipeline(1, newIssuesBuffer(m.target.ListIssues(context.Background())))
+	issue := newImportPipelineTestIssue(1)
+	issue.Body = "Example body"
+	require.NoError(t, p.push(context.Background(), issue, false))
+	require.NoError(t, p.wait(context.Background(), 0))
+	assert.Equal(t, 1, q.lastNumber)
+	require.Len(t, q.imports, 2)
+	prov, body, ok := parseProvenance(q.imports[1].Issue.Body)
+	require.True(t, ok)
+	assert.Contains(t, body, "(The body is truncated.")
+	assert.Equal(t, contentHash(body), prov.ContentHash)
+}
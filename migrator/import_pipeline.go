@@ -0,0 +1,166 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// PipelineImports returns a migrator option to keep the imports of the issues
+// in flight up to the number, instead of waiting for each import before the
+// next one. The imports are submitted in order of the issue numbers, and their
+// statuses are polled together. Since a failed import does not take the issue
+// number, the statuses are checked before submitting each import, and a failed
+// import stops the submission until it is recovered (or replaced with the
+// placeholder) one by one. The migration stops when the issue numbers turn out
+// to be misaligned, that is, a later import in flight has taken the number.
+func PipelineImports(n int) Option {
+	return func(m *migrator) {
+		m.importPipelineSize = n
+	}
+}
+
+type importPipeline struct {
+	m                  *migrator
+	size               int
+	targetIssuesBuffer *issuesBuffer
+	pending            []*pendingImport
+}
+
+type pendingImport struct {
+	issue   *github.Issue
+	deleted bool
+	result  *github.ImportResult
+}
+
+func (m *migrator) newImportPipeline(size int, targetIssuesBuffer *issuesBuffer) *importPipeline {
+	return &importPipeline{m: m, size: size, targetIssuesBuffer: targetIssuesBuffer}
+}
+
+// push submits the import of the issue, and waits for the imports in flight
+// while the pipeline is full. The failed imports are recovered before
+// submitting the import, so that the recovery takes the freed issue number.
+func (p *importPipeline) push(ctx context.Context, issue *github.Issue, deleted bool) error {
+	if len(p.pending) > 0 {
+		if _, err := p.poll(ctx); err != nil {
+			return err
+		}
+	}
+	result, err := p.m.migrateIssue(ctx, issue, p.targetIssuesBuffer, deleted, 0)
+	if err != nil {
+		// the imports in flight should be completed before recovering
+		if err := p.wait(ctx, 0); err != nil {
+			return err
+		}
+		return p.recover(ctx, issue, deleted, err)
+	}
+	if result != nil {
+		p.pending = append(p.pending, &pendingImport{issue, deleted, result})
+	}
+	return p.wait(ctx, p.size-1)
+}
+
+func (p *importPipeline) recover(ctx context.Context, issue *github.Issue, deleted bool, err error) error {
+	if err := p.m.recoverIssue(ctx, issue, p.targetIssuesBuffer, deleted, err); err != nil {
+		return p.m.handleIssueFailure(ctx, issue, err)
+	}
+	return nil
+}
+
+// wait polls the statuses until the imports in flight are reduced to n. The
+// polling is not canceled so that the imports in flight are completed.
+func (p *importPipeline) wait(ctx context.Context, n int) error {
+	var retry int
+	for len(p.pending) > n {
+		if err := p.m.pacing.PollImport(withoutCancel(ctx), retry); err != nil {
+			return fmt.Errorf("importing %s failed: %w", p.pending[0].issue.HTMLURL, err)
+		}
+		done, err := p.poll(ctx)
+		if err != nil {
+			return err
+		}
+		if done > 0 {
+			retry = 0
+		} else {
+			retry++
+		}
+	}
+	return nil
+}
+
+// poll updates the statuses of the imports in flight, and resolves the
+// completed imports in order. It returns the number of resolved imports.
+func (p *importPipeline) poll(ctx context.Context) (int, error) {
+	results, err := p.m.target.ListImports(withoutCancel(ctx), p.pending[0].result.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	resultByIDs := make(map[int]*github.ImportResult, len(results))
+	for _, r := range results {
+		resultByIDs[r.ID] = r
+	}
+	for _, x := range p.pending {
+		if r, ok := resultByIDs[x.result.ID]; ok {
+			x.result = r
+		}
+	}
+	if x := p.pending[0]; resultByIDs[x.result.ID] == nil {
+		if x.result, err = p.m.target.GetImport(withoutCancel(ctx), x.result.ID); err != nil {
+			return 0, err
+		}
+	}
+	var done int
+	for len(p.pending) > 0 {
+		x := p.pending[0]
+		switch x.result.Status {
+		case "imported":
+			fmt.Printf("[<>] checking status: %s (importing %s)\n", x.result.Status, x.issue.HTMLURL)
+			if err := checkImportedNumber(x.result, x.issue, p.m.targetIssueNumber(x.issue.Number)); err != nil {
+				return done, err
+			}
+			p.m.recordIssueURL(x.issue)
+			if err := p.m.reconcileIssue(ctx, x.issue); err != nil {
+				return done, err
+			}
+			p.pending = p.pending[1:]
+			done++
+		case "failed":
+			fmt.Printf("[!!] checking status: %s (importing %s)\n", x.result.Status, x.issue.HTMLURL)
+			p.pending = p.pending[1:]
+			done++
+			return done, p.recoverFailed(ctx, x)
+		default:
+			fmt.Printf("[??] checking status: %s (importing %s, %s in flight)\n",
+				x.result.Status, x.issue.HTMLURL, plural(len(p.pending), "import"))
+			return done, nil
+		}
+	}
+	return done, nil
+}
+
+// recoverFailed recovers the failed import after the later imports in flight.
+// Since the failed import does not take the issue number, the issue numbers
+// are misaligned when any of the later imports succeeds.
+func (p *importPipeline) recoverFailed(ctx context.Context, failed *pendingImport) error {
+	xs := append([]*pendingImport{failed}, p.pending...)
+	p.pending = nil
+	errs := make([]error, len(xs))
+	errs[0] = fmt.Errorf("importing %s failed: %w", failed.issue.HTMLURL, importFailedError(failed.result))
+	for i, x := range xs[1:] {
+		_, err := p.m.waitImportIssue(ctx, x.result.ID, x.issue)
+		if err == nil {
+			return fmt.Errorf(
+				"%w (%s is imported in advance and the issue numbers are misaligned)",
+				errs[0], x.issue.HTMLURL,
+			)
+		}
+		errs[i+1] = fmt.Errorf("importing %s failed: %w", x.issue.HTMLURL, err)
+	}
+	for i, x := range xs {
+		if err := p.recover(ctx, x.issue, x.deleted, errs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
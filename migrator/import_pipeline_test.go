@@ -0,0 +1,229 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// importQueue imitates the import API, which processes the imports in order,
+// and a failed import does not take the issue number. Each import is processed
+// after the delay (the number of the requests to check the statuses).
+type importQueue struct {
+	delay      int
+	tick       int
+	ticks      []int
+	results    []*github.ImportResult
+	titles     []string
+	processed  int
+	lastNumber int
+	inFlight   int
+	maxFlight  int
+	fail       func(*github.Import) github.APIErrors
+	imports    []*github.Import
+	onSubmit   func()
+}
+
+func (q *importQueue) submit(_ string, imp *github.Import) (*github.ImportResult, error) {
+	q.imports = append(q.imports, imp)
+	r := &github.ImportResult{ID: len(q.results) + 1, Status: "pending"}
+	if errs := q.fail(imp); errs != nil {
+		r.Errors = errs
+	}
+	q.results = append(q.results, r)
+	q.ticks = append(q.ticks, q.tick)
+	q.titles = append(q.titles, imp.Issue.Title)
+	if q.inFlight++; q.inFlight > q.maxFlight {
+		q.maxFlight = q.inFlight
+	}
+	if q.onSubmit != nil {
+		q.onSubmit()
+	}
+	return &github.ImportResult{ID: r.ID, Status: "pending"}, nil
+}
+
+func (q *importQueue) process() {
+	q.tick++
+	if q.processed < len(q.results) && q.tick-q.ticks[q.processed] > q.delay {
+		r := q.results[q.processed]
+		if r.Errors != nil {
+			r.Status = "failed"
+		} else {
+			r.Status = "imported"
+			q.lastNumber++
+			r.IssueURL = fmt.Sprintf("http://localhost/api/v3/repos/example/target/issues/%d", q.lastNumber)
+		}
+		q.processed++
+		q.inFlight--
+	}
+}
+
+func (q *importQueue) list(string, string) ([]*github.ImportResult, error) {
+	q.process()
+	results := make([]*github.ImportResult, len(q.results))
+	for i, r := range q.results {
+		x := *r
+		results[i] = &x
+	}
+	return results, nil
+}
+
+func (q *importQueue) get(_ string, id int) (*github.ImportResult, error) {
+	q.process()
+	x := *q.results[id-1]
+	return &x, nil
+}
+
+func newImportPipelineTestMigrator(q *importQueue, opts ...Option) *migrator {
+	source := repo.New(github.NewMockClient(
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			var issues []*github.Issue
+			for i := 1; i <= 10; i++ {
+				issues = append(issues, newImportPipelineTestIssue(i))
+			}
+			return github.IssuesFromSlice(issues)
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice(nil)
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+		github.MockListTimeline(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+	), "example/source")
+	target := repo.New(github.NewMockClient(
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice(nil)
+		}),
+		github.MockImport(q.submit),
+		github.MockListImports(q.list),
+		github.MockGetImport(q.get),
+		github.MockGetUser(func(string) (*github.User, error) {
+			return nil, errors.New("Not Found")
+		}),
+	), "example/target")
+	m := New(source, target, nil, opts...).(*migrator)
+	m.sourceRepo = &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	return m
+}
+
+func newImportPipelineTestIssue(number int) *github.Issue {
+	return &github.Issue{
+		Number:    number,
+		Title:     fmt.Sprintf("Example title %d", number),
+		State:     github.IssueStateOpen,
+		HTMLURL:   fmt.Sprintf("http://localhost/example/source/issues/%d", number),
+		User:      &github.User{Login: "sample-user-1"},
+		CreatedAt: "2019-11-10T12:00:00Z",
+	}
+}
+
+func TestImportPipeline(t *testing.T) {
+	q := &importQueue{delay: 3, fail: func(*github.Import) github.APIErrors { return nil }}
+	m := newImportPipelineTestMigrator(q)
+	p := m.newImportPipeline(3, newIssuesBuffer(m.target.ListIssues(context.Background())))
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, p.push(context.Background(), newImportPipelineTestIssue(i), i == 5))
+	}
+	require.NoError(t, p.wait(context.Background(), 0))
+	assert.Equal(t, 3, q.maxFlight)
+	assert.Equal(t, 10, q.lastNumber)
+	require.Len(t, q.titles, 10)
+	for i, title := range q.titles {
+		if i+1 == 5 {
+			assert.Equal(t, "[Deleted issue]", title)
+		} else {
+			assert.Equal(t, fmt.Sprintf("Example title %d", i+1), title)
+		}
+	}
+}
+
+func TestImportPipelineRecover(t *testing.T) {
+	q := &importQueue{fail: func(imp *github.Import) github.APIErrors {
+		if len(imp.Issue.Labels) > 0 && len(imp.Issue.Labels[0]) > maxLabelLength {
+			return github.APIErrors{{Resource: "Label", Field: "name", Code: "invalid"}}
+		}
+		return nil
+	}}
+	m := newImportPipelineTestMigrator(q)
+	p := m.newImportPipeline(2, newIssuesBuffer(m.target.ListIssues(context.Background())))
+	issue := newImportPipelineTestIssue(1)
+	require.NoError(t, p.push(context.Background(), issue, false))
+	issue = newImportPipelineTestIssue(2)
+	issue.Labels = []*github.Label{{Name: strings.Repeat("x", maxLabelLength+10)}}
+	require.NoError(t, p.push(context.Background(), issue, false))
+	require.NoError(t, p.wait(context.Background(), 0))
+	assert.Equal(t, 2, q.lastNumber)
+	assert.Equal(t, []string{"Example title 1", "Example title 2", "Example title 2"}, q.titles)
+	assert.Equal(t, []string{strings.Repeat("x", maxLabelLength)}, q.imports[2].Issue.Labels)
+}
+
+func TestImportPipelineRecoverAligned(t *testing.T) {
+	q := &importQueue{fail: func(imp *github.Import) github.APIErrors {
+		if len(imp.Issue.Labels) > 0 && len(imp.Issue.Labels[0]) > maxLabelLength {
+			return github.APIErrors{{Resource: "Label", Field: "name", Code: "invalid"}}
+		}
+		return nil
+	}}
+	m := newImportPipelineTestMigrator(q)
+	p := m.newImportPipeline(3, newIssuesBuffer(m.target.ListIssues(context.Background())))
+	for i := 1; i <= 10; i++ {
+		issue := newImportPipelineTestIssue(i)
+		if i == 2 {
+			issue.Labels = []*github.Label{{Name: strings.Repeat("x", maxLabelLength+10)}}
+		}
+		require.NoError(t, p.push(context.Background(), issue, false))
+	}
+	require.NoError(t, p.wait(context.Background(), 0))
+	assert.Equal(t, 10, q.lastNumber)
+	require.Len(t, q.titles, 11)
+	assert.Equal(t, []string{"Example title 1", "Example title 2", "Example title 2", "Example title 3"}, q.titles[:4])
+	assert.Equal(t, "Example title 10", q.titles[10])
+}
+
+func TestImportPipelineMisaligned(t *testing.T) {
+	q := &importQueue{delay: 3, fail: func(imp *github.Import) github.APIErrors {
+		if imp.Issue.Title == "Example title 2" {
+			return github.APIErrors{{Resource: "Issue", Field: "body", Code: "invalid"}}
+		}
+		return nil
+	}}
+	m := newImportPipelineTestMigrator(q)
+	p := m.newImportPipeline(3, newIssuesBuffer(m.target.ListIssues(context.Background())))
+	var err error
+	for i := 1; i <= 4 && err == nil; i++ {
+		err = p.push(context.Background(), newImportPipelineTestIssue(i), false)
+	}
+	if err == nil {
+		err = p.wait(context.Background(), 0)
+	}
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "misaligned")
+}
+
+func TestImportPipelineCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &importQueue{fail: func(*github.Import) github.APIErrors { return nil }}
+	q.onSubmit = func() {
+		if len(q.results) == 5 {
+			cancel()
+		}
+	}
+	m := newImportPipelineTestMigrator(q, PipelineImports(3))
+	err := m.migrateIssues(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Len(t, q.results, 5)
+	assert.Equal(t, 5, q.processed)
+	assert.Equal(t, 5, q.lastNumber)
+}
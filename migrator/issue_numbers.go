@@ -0,0 +1,170 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// OffsetNumbers returns a migrator option to import the issues into the target
+// repository which already has issues (for example, when several repositories
+// are merged into one). The issues are numbered sequentially after the highest
+// number in the target, and the references to the issues (like #123 and the
+// issue URLs), the events and the project cards are rewritten with the new
+// numbers. The deleted issues are not created.
+func OffsetNumbers() Option {
+	return func(m *migrator) {
+		m.offsetNumbers = true
+	}
+}
+
+// buildTargetNumbers builds the mapping from the source issue numbers to the
+// target numbers. The issues imported in the previous migration are looked up
+// by the provenance metadata, so that the migration can be resumed.
+func (m *migrator) buildTargetNumbers(ctx context.Context) error {
+	numbering, err := newTargetNumbering(ctx, m.target)
+	if err != nil {
+		return err
+	}
+	sourceIssues := m.source.ListIssues(ctx)
+	m.targetNumbers = make(map[int]int)
+	for {
+		issue, err := sourceIssues.Next()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			return nil
+		}
+		m.targetNumbers[issue.Number] = numbering.next(issue)
+	}
+}
+
+// targetNumbering numbers the source issues sequentially after the highest
+// number in the target, except for the issues imported previously.
+type targetNumbering struct {
+	lastNumber      int
+	importedNumbers map[string]int
+}
+
+func newTargetNumbering(ctx context.Context, target *repo.Repo) (*targetNumbering, error) {
+	targetIssues, err := github.IssuesToSlice(target.ListIssues(ctx))
+	if err != nil {
+		return nil, err
+	}
+	n := &targetNumbering{importedNumbers: make(map[string]int)}
+	for _, issue := range targetIssues {
+		if n.lastNumber < issue.Number {
+			n.lastNumber = issue.Number
+		}
+		if p, _, ok := parseProvenance(issue.Body); ok && p.SourceURL != "" {
+			n.importedNumbers[p.SourceURL] = issue.Number
+		}
+	}
+	fmt.Printf("[<>] numbering the issues after #%d\n", n.lastNumber)
+	return n, nil
+}
+
+func (n *targetNumbering) next(sourceIssue *github.Issue) int {
+	if number, ok := n.importedNumbers[sourceIssue.HTMLURL]; ok {
+		return number
+	}
+	n.lastNumber++
+	return n.lastNumber
+}
+
+// targetIssueNumber returns the number of the target issue migrated from the
+// source issue. This returns 0 if the issue is not migrated.
+func (m *migrator) targetIssueNumber(number int) int {
+	if m.targetNumbers == nil {
+		return number
+	}
+	return m.targetNumbers[number]
+}
+
+// checkImportedNumber checks the issue number of the imported issue is the
+// same as expected.
+func checkImportedNumber(res *github.ImportResult, issue *github.Issue, number int) error {
+	if res.IssueURL == "" {
+		return nil
+	}
+	i := strings.LastIndexByte(res.IssueURL, '/')
+	if n, err := strconv.Atoi(res.IssueURL[i+1:]); err == nil && n != number {
+		return fmt.Errorf(
+			"importing %s resulted in issue #%d (the issue numbers are misaligned)",
+			issue.HTMLURL, n,
+		)
+	}
+	return nil
+}
+
+// newIssueNumberFilter creates a filter to rewrite the references to the issues
+// of the source repository in the texts of the repository (textRepo, which is
+// different from the source on merging the repositories) to the target. The
+// references to the issues which are not migrated (like the deleted issues)
+// are qualified with the source repository, and the links are kept. The code
+// blocks and code spans are not rewritten.
+func newIssueNumberFilter(targetNumbers map[int]int, sourceRepo, textRepo, targetRepo *github.Repo) commentFilter {
+	if targetNumbers == nil {
+		return commentFilter(func(src string) string {
+			return src
+		})
+	}
+	refNames := []string{regexp.QuoteMeta(sourceRepo.FullName)}
+	owner, name := splitFullName(sourceRepo.FullName)
+	if textOwner, _ := splitFullName(textRepo.FullName); strings.EqualFold(owner, textOwner) {
+		refNames = append(refNames, regexp.QuoteMeta(name))
+	}
+	refPrefix := `(?i:` + strings.Join(refNames, "|") + `)#`
+	if sourceRepo.FullName == textRepo.FullName {
+		refPrefix += `|GH-|#`
+	}
+	pattern := regexp.MustCompile(
+		"(?s)```.*?```|`[^`\n]*`|" +
+			regexp.QuoteMeta(sourceRepo.HTMLURL) + `/(issues|pull)/(\d+)\b|` +
+			`(^|[^-\w&#/.])(` + refPrefix + `)(\d+)\b`,
+	)
+	return commentFilter(func(src string) string {
+		var s strings.Builder
+		var last int
+		for _, xs := range pattern.FindAllStringSubmatchIndex(src, -1) {
+			s.WriteString(src[last:xs[0]])
+			last = xs[1]
+			switch {
+			case xs[2] >= 0: // link to the issue
+				kind := src[xs[2]:xs[3]]
+				number, _ := strconv.Atoi(src[xs[4]:xs[5]])
+				if n, ok := targetNumbers[number]; ok {
+					fmt.Fprintf(&s, "%s/%s/%d", targetRepo.HTMLURL, kind, n)
+					continue
+				}
+			case xs[6] >= 0: // reference to the issue
+				s.WriteString(src[xs[6]:xs[7]])
+				prefix := src[xs[8]:xs[9]]
+				number, _ := strconv.Atoi(src[xs[10]:xs[11]])
+				n, ok := targetNumbers[number]
+				if prefix == "#" || prefix == "GH-" {
+					if ok {
+						fmt.Fprintf(&s, "%s%d", prefix, n)
+					} else {
+						fmt.Fprintf(&s, "%s#%d", sourceRepo.FullName, number)
+					}
+				} else if ok {
+					fmt.Fprintf(&s, "%s#%d", targetRepo.FullName, n)
+				} else {
+					s.WriteString(src[xs[8]:xs[11]])
+				}
+				continue
+			}
+			s.WriteString(src[xs[0]:xs[1]])
+		}
+		s.WriteString(src[last:])
+		return s.String()
+	})
+}
@@ -0,0 +1,133 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestIssueNumberFilter(t *testing.T) {
+	sourceRepo := &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	targetRepo := &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	f := newIssueNumberFilter(map[int]int{1: 11, 2: 12, 5: 15}, sourceRepo, sourceRepo, targetRepo)
+	for _, tc := range []struct {
+		src, expected string
+	}{
+		{"#1", "#11"},
+		{"Fixes #1, #2 and #3.", "Fixes #11, #12 and example/source#3."},
+		{"(#5) [#2] GH-3", "(#15) [#12] example/source#3"},
+		{"GH-2 example/source#5 Example/Source#1", "GH-12 example/target#15 example/target#11"},
+		{"source#1 other/source#1 example/sources#1", "example/target#11 other/source#1 example/sources#1"},
+		{"example/source#3 source#3", "example/source#3 source#3"},
+		{"x#1 &#1; ##1", "x#1 &#1; ##1"},
+		{"#123 #1a", "example/source#123 #1a"},
+		{"Fixed in #1 (not `#2` or\n```css\ncolor: #5;\n```\n)", "Fixed in #11 (not `#2` or\n```css\ncolor: #5;\n```\n)"},
+		{
+			"http://localhost/example/source/issues/1#issuecomment-1 http://localhost/example/source/pull/5/files",
+			"http://localhost/example/target/issues/11#issuecomment-1 http://localhost/example/target/pull/15/files",
+		},
+		{
+			"http://localhost/example/source/issues/3 http://localhost/example/other/issues/1",
+			"http://localhost/example/source/issues/3 http://localhost/example/other/issues/1",
+		},
+	} {
+		assert.Equal(t, tc.expected, f(tc.src), tc.src)
+	}
+}
+
+func TestMigrateIssuesOffsetNumbers(t *testing.T) {
+	sourceIssues := []*github.Issue{
+		{
+			Number:  1,
+			Title:   "Example title 1",
+			HTMLURL: "http://localhost/example/source/issues/1",
+			User:    &github.User{Login: "sample-user-1"},
+		},
+		{
+			Number:  2,
+			Title:   "Example title 2",
+			Body:    "Related to #1 and #4 (not #3).",
+			HTMLURL: "http://localhost/example/source/issues/2",
+			User:    &github.User{Login: "sample-user-1"},
+		},
+		{
+			Number:  4,
+			Title:   "Example title 4",
+			Body:    "See http://localhost/example/source/issues/2.",
+			HTMLURL: "http://localhost/example/source/issues/4",
+			User:    &github.User{Login: "sample-user-1"},
+		},
+	}
+	source := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}, nil
+		}),
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice(sourceIssues)
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice(nil)
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+		github.MockListTimeline(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+	), "example/source")
+	p := &provenance{SourceURL: "http://localhost/example/source/issues/1"}
+	var imports []*github.Import
+	target := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice(nil)
+		}),
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{
+				{
+					Number:  10,
+					Title:   "Existing issue",
+					HTMLURL: "http://localhost/example/target/issues/10",
+				},
+				{
+					Number:  11,
+					Title:   "Example title 1",
+					Body:    p.embed("imported", 0),
+					HTMLURL: "http://localhost/example/target/issues/11",
+				},
+			})
+		}),
+		github.MockGetUser(func(string) (*github.User, error) {
+			return nil, errors.New("Not Found")
+		}),
+		github.MockImport(func(_ string, imp *github.Import) (*github.ImportResult, error) {
+			imports = append(imports, imp)
+			return &github.ImportResult{ID: len(imports), Status: "pending"}, nil
+		}),
+		github.MockGetImport(func(_ string, id int) (*github.ImportResult, error) {
+			return &github.ImportResult{
+				ID: id, Status: "imported",
+				IssueURL: fmt.Sprintf("http://localhost/api/v3/repos/example/target/issues/%d", 11+id),
+			}, nil
+		}),
+	), "example/target")
+
+	m := New(source, target, nil, OffsetNumbers()).(*migrator)
+	require.NoError(t, m.init(context.Background()))
+	assert.Equal(t, map[int]int{1: 11, 2: 12, 4: 13}, m.targetNumbers)
+	require.NoError(t, m.migrateIssues(context.Background()))
+	require.Len(t, imports, 2)
+	assert.Equal(t, "Example title 2", imports[0].Issue.Title)
+	assert.Contains(t, imports[0].Issue.Body, "Related to #11 and #13 (not example/source#3).")
+	assert.Equal(t, "Example title 4", imports[1].Issue.Title)
+	assert.Contains(t, imports[1].Issue.Body, "See http://localhost/example/target/issues/12.")
+}
@@ -0,0 +1,117 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// reconcileIssue applies the states of the source issue which the import API
+// cannot express (all the assignees, the close reason and the locked
+// conversation) to the imported issue.
+func (m *migrator) reconcileIssue(ctx context.Context, sourceIssue *github.Issue) error {
+	if err := m.addAssignees(ctx, sourceIssue); err != nil {
+		return err
+	}
+	if err := m.closeIssueWithReason(ctx, sourceIssue); err != nil {
+		return err
+	}
+	return m.lockIssue(ctx, sourceIssue)
+}
+
+// closeIssueWithReason sets the close reason of the imported issue, which is
+// closed as completed on importing.
+func (m *migrator) closeIssueWithReason(ctx context.Context, sourceIssue *github.Issue) error {
+	if sourceIssue.State != github.IssueStateClosed ||
+		sourceIssue.StateReason == "" || sourceIssue.StateReason == "completed" {
+		return nil
+	}
+	fmt.Printf("[>>] closing the issue as %s (original: %s)\n", sourceIssue.StateReason, sourceIssue.HTMLURL)
+	if _, err := m.target.CloseIssue(
+		withoutCancel(ctx), m.targetIssueNumber(sourceIssue.Number), sourceIssue.StateReason,
+	); err != nil {
+		return m.reportStateDropped(sourceIssue, "state_reason", sourceIssue.StateReason, err)
+	}
+	return nil
+}
+
+// lockIssue locks the conversation of the imported issue. This should be
+// applied at last, after all the comments are posted.
+func (m *migrator) lockIssue(ctx context.Context, sourceIssue *github.Issue) error {
+	if !sourceIssue.Locked {
+		return nil
+	}
+	fmt.Printf("[>>] locking the conversation (original: %s)\n", sourceIssue.HTMLURL)
+	if err := m.target.LockIssue(
+		withoutCancel(ctx), m.targetIssueNumber(sourceIssue.Number), sourceIssue.LockReason,
+	); err != nil {
+		return m.reportStateDropped(sourceIssue, "lock", sourceIssue.LockReason, err)
+	}
+	return nil
+}
+
+// migratePinnedIssues pins the same issues as the source repository. This is
+// applied after the issues are migrated, since the pinned issues are not
+// ordered by the issue numbers. The pinning is skipped when the pinned issues
+// are not available (for example, on the older GitHub Enterprise Server).
+func (m *migrator) migratePinnedIssues(ctx context.Context) error {
+	fmt.Printf("[=>] migrating pinned issues\n")
+	sourcePinned, err := m.source.ListPinnedIssues(ctx)
+	if err != nil {
+		return m.skipPinnedIssues(ctx, m.sourceRepo, err)
+	}
+	if len(sourcePinned) == 0 {
+		return nil
+	}
+	targetPinned, err := m.target.ListPinnedIssues(ctx)
+	if err != nil {
+		return m.skipPinnedIssues(ctx, m.targetRepo, err)
+	}
+	pinned := make(map[int]bool, len(targetPinned))
+	for _, number := range targetPinned {
+		pinned[number] = true
+	}
+	for _, number := range sourcePinned {
+		issue := &github.Issue{
+			Number:  number,
+			HTMLURL: fmt.Sprintf("%s/issues/%d", m.sourceRepo.HTMLURL, number),
+		}
+		targetNumber := m.targetIssueNumber(number)
+		if targetNumber == 0 {
+			fmt.Printf("[--] skipping: %s (not migrated)\n", issue.HTMLURL)
+			continue
+		}
+		if pinned[targetNumber] {
+			fmt.Printf("[--] skipping: %s (already pinned)\n", issue.HTMLURL)
+			continue
+		}
+		fmt.Printf("[>>] pinning the issue (original: %s)\n", issue.HTMLURL)
+		if err := m.target.PinIssue(ctx, targetNumber); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			if err := m.reportStateDropped(issue, "pin", "pinned", err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *migrator) skipPinnedIssues(ctx context.Context, repo *github.Repo, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return m.reportStateDropped(&github.Issue{HTMLURL: repo.HTMLURL}, "pin", "pinned issues", err)
+}
+
+func (m *migrator) reportStateDropped(issue *github.Issue, location, state string, err error) error {
+	fmt.Printf("[!!] dropped the state %s: %s (%s)\n", state, issue.HTMLURL, err)
+	return m.report(&reportEntry{
+		Type:     "state_dropped",
+		URL:      issue.HTMLURL,
+		Location: location,
+		Message:  fmt.Sprintf("dropped %s (%s)", state, err),
+	})
+}
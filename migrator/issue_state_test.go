@@ -0,0 +1,34 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestMigratePinnedIssuesUnavailable(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockListPinnedIssues(func(string) ([]int, error) {
+			return []int{1}, nil
+		}),
+	), "example/source")
+	target := repo.New(github.NewMockClient(
+		github.MockListPinnedIssues(func(string) ([]int, error) {
+			return nil, errors.New("Field 'pinnedIssues' doesn't exist on type 'Repository'")
+		}),
+	), "example/target")
+	report := new(bytes.Buffer)
+	m := New(source, target, nil, Report(report)).(*migrator)
+	m.sourceRepo = &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"}
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"}
+	require.NoError(t, m.migratePinnedIssues(context.Background()))
+	assert.Equal(t, `{"type":"state_dropped","url":"http://localhost/example/target","location":"pin","message":"dropped pinned issues (Field 'pinnedIssues' doesn't exist on type 'Repository')"}
+`, report.String())
+}
@@ -1,23 +1,40 @@
 package migrator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"strings"
-	"time"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-var (
-	beforeImportIssueDuration      = 500 * time.Millisecond
-	waitImportIssueInitialDuration = 1 * time.Second
-)
+func (m *migrator) migrateIssues(ctx context.Context) error {
+	// stop the list producers when returned early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	targetIssuesBuffer := newIssuesBuffer(m.target.ListIssues(ctx))
+	var pipeline *importPipeline
+	if m.importPipelineSize > 1 {
+		pipeline = m.newImportPipeline(m.importPipelineSize, targetIssuesBuffer)
+	}
+	err := m.migrateSourceIssues(ctx, targetIssuesBuffer, pipeline)
+	if pipeline != nil && (err == nil || ctx.Err() != nil) {
+		// the imports in flight are completed even on the graceful shutdown
+		if err := pipeline.wait(ctx, 0); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
 
-func (m *migrator) migrateIssues() error {
-	sourceIssues := m.source.ListIssues()
-	targetIssuesBuffer := newIssuesBuffer(m.target.ListIssues())
+func (m *migrator) migrateSourceIssues(
+	ctx context.Context, targetIssuesBuffer *issuesBuffer, pipeline *importPipeline,
+) error {
+	sourceIssues := m.source.ListIssues(ctx)
 	var lastIssueNumber int
 	for {
 		issue, err := sourceIssues.Next()
@@ -25,9 +42,16 @@ func (m *migrator) migrateIssues() error {
 			if err != io.EOF {
 				return err
 			}
-			break
+			return nil
+		}
+		if m.targetNumbers != nil {
+			// the deleted issues are not created since the numbers are offset
+			lastIssueNumber = issue.Number - 1
 		}
 		for ; issue.Number > lastIssueNumber; lastIssueNumber++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			issue := issue
 			var deleted bool
 			if deleted = issue.Number > lastIssueNumber+1; deleted {
@@ -39,56 +63,97 @@ func (m *migrator) migrateIssues() error {
 					ClosedAt:  issue.CreatedAt,
 				}
 			}
-			result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, false)
-			if err != nil {
-				return err
-			}
-			if result != nil {
-				if err := m.waitImportIssue(result.ID, issue); err != nil {
-					if !strings.Contains(err.Error(), "Issue.assignee") {
-						return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
-					}
-					result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, true)
-					if err != nil {
-						return err
-					}
-					if result != nil {
-						if err := m.waitImportIssue(result.ID, issue); err != nil {
-							return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
-						}
-					}
+			if pipeline != nil {
+				if err := pipeline.push(ctx, issue, deleted); err != nil {
+					return err
+				}
+			} else if err := m.migrateAndWaitIssue(ctx, issue, targetIssuesBuffer, deleted); err != nil {
+				if err := m.handleIssueFailure(ctx, issue, err); err != nil {
+					return err
 				}
 			}
 		}
 	}
+}
+
+func (m *migrator) handleIssueFailure(ctx context.Context, issue *github.Issue, err error) error {
+	var collisionErr *issueCollisionError
+	// the placeholder issues are not created when the numbers are offset
+	if m.errorPolicy != ErrorPolicyPlaceholder || m.targetNumbers != nil ||
+		ctx.Err() != nil || errors.As(err, &collisionErr) {
+		return err
+	}
+	return m.migratePlaceholderIssue(ctx, issue, err)
+}
+
+func (m *migrator) migrateAndWaitIssue(
+	ctx context.Context, issue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted bool,
+) error {
+	result, err := m.migrateIssue(ctx, issue, targetIssuesBuffer, deleted, 0)
+	if err == nil && result != nil {
+		err = m.waitImportResult(ctx, result, issue)
+	}
+	return m.recoverIssue(ctx, issue, targetIssuesBuffer, deleted, err)
+}
+
+// recoverIssue migrates the issue again with the degradations to recover the
+// failure, until it succeeds or no more degradation is applicable.
+func (m *migrator) recoverIssue(
+	ctx context.Context, issue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted bool, err error,
+) error {
+	var degradations importDegradation
+	for err != nil {
+		d := importDegradationOf(err) &^ degradations
+		if d == 0 || deleted {
+			return err
+		}
+		if err := m.reportDegradation(issue, d, err); err != nil {
+			return err
+		}
+		degradations |= d
+		var result *github.ImportResult
+		result, err = m.migrateIssue(ctx, issue, targetIssuesBuffer, deleted, degradations)
+		if err == nil && result != nil {
+			err = m.waitImportResult(ctx, result, issue)
+		}
+	}
 	return nil
 }
 
 func (m *migrator) migrateIssue(
-	sourceIssue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted, skipAssignee bool,
+	ctx context.Context, sourceIssue *github.Issue, targetIssuesBuffer *issuesBuffer,
+	deleted bool, degradations importDegradation,
 ) (*github.ImportResult, error) {
 	fmt.Printf("[=>] migrating an issue: %s\n", sourceIssue.HTMLURL)
-	targetIssue, err := targetIssuesBuffer.get(sourceIssue.Number)
+	targetIssue, err := targetIssuesBuffer.get(m.targetIssueNumber(sourceIssue.Number))
 	if err != nil {
 		return nil, err
 	}
 	if targetIssue != nil {
+		if err := m.checkIssueCollision(targetIssue, sourceIssue); err != nil {
+			return nil, err
+		}
 		fmt.Printf("[--] skipping: %s (already exists)\n", targetIssue.HTMLURL)
 		m.cacheIssueID(targetIssue.Number, targetIssue.ID)
+		m.recordIssueURL(sourceIssue)
 		return nil, nil
 	}
-	time.Sleep(beforeImportIssueDuration)
+	if err := m.pacing.BeforeImport(ctx); err != nil {
+		return nil, err
+	}
 	if deleted {
 		fmt.Printf("[>>] creating a new issue: (original: %s is deleted)\n", sourceIssue.HTMLURL)
-		return m.target.Import(&github.Import{
+		p := m.newIssueProvenance(sourceIssue)
+		p.SourceType = "deleted_issue"
+		return m.target.Import(withoutCancel(ctx), &github.Import{
 			Issue: &github.ImportIssue{
 				Title: "[Deleted issue]",
-				Body: fmt.Sprintf(`<table>
+				Body: p.embed(fmt.Sprintf(`<table>
 <tr>
   <td>This issue was imported from %s, which has already been deleted.</td>
 </tr>
 </table>
-`, buildIssueLinkTag(m.sourceRepo, sourceIssue)),
+`, buildIssueLinkTag(m.sourceRepo, sourceIssue)), 0),
 				CreatedAt: sourceIssue.CreatedAt,
 				UpdatedAt: sourceIssue.UpdatedAt,
 				Closed:    true,
@@ -97,89 +162,128 @@ func (m *migrator) migrateIssue(
 			Comments: []*github.ImportComment{},
 		})
 	}
-	comments, err := github.CommentsToSlice(m.source.ListComments(sourceIssue.Number))
+	imp, err := m.buildIssueImport(ctx, sourceIssue, degradations)
 	if err != nil {
 		return nil, err
 	}
-	events, err := github.EventsToSlice(m.source.ListEvents(sourceIssue.Number))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	fmt.Printf("[>>] creating a new issue: (original: %s)\n", sourceIssue.HTMLURL)
+	m.setImportedAssignee(sourceIssue, imp.Issue.Assignee)
+	return m.target.Import(withoutCancel(ctx), imp)
+}
+
+func (m *migrator) buildIssueImport(
+	ctx context.Context, sourceIssue *github.Issue, degradations importDegradation,
+) (*github.Import, error) {
+	comments, err := github.CommentsToSlice(m.source.ListComments(ctx, sourceIssue.Number))
 	if err != nil {
 		return nil, err
 	}
+	events, err := github.EventsToSlice(m.source.ListEvents(ctx, sourceIssue.Number))
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := github.EventsToSlice(m.source.ListTimeline(ctx, sourceIssue.Number))
+	if err != nil {
+		return nil, err
+	}
+	events = mergeTimelineEvents(events, timeline)
 	var sourcePullReq *github.PullReq
 	var commits []*github.Commit
+	var localCommits bool
 	var commitDiff string
+	var missingDiff string
 	var reviews []*github.Review
 	var reviewComments []*github.ReviewComment
 	if sourceIssue.PullRequest != nil {
-		sourcePullReq, err = m.source.GetPullReq(sourceIssue.Number)
+		sourcePullReq, err = m.source.GetPullReq(ctx, sourceIssue.Number)
 		if err != nil {
 			return nil, err
 		}
-		commits, err = github.CommitsToSlice(m.source.ListPullReqCommits(sourceIssue.Number))
-		if err != nil {
+		if _, err = m.fetchPullReqDiff(ctx, sourcePullReq, "commits", func(p DiffProvider) (err error) {
+			commits, err = p.ListCommits(ctx, sourcePullReq)
+			_, localCommits = p.(*gitDiffProvider)
+			return
+		}); err != nil {
 			return nil, err
 		}
-		commitDiff, err = m.source.NewPath(sourcePullReq.Base.Repo.FullName).
-			GetCompare(sourcePullReq.Base.SHA, sourcePullReq.Head.SHA)
-		if err != nil {
+		if missingDiff, err = m.fetchPullReqDiff(ctx, sourcePullReq, "diff", func(p DiffProvider) (err error) {
+			commitDiff, err = p.GetDiff(ctx, sourcePullReq)
+			return
+		}); err != nil {
 			return nil, err
 		}
-		reviews, err = github.ReviewsToSlice(m.source.ListReviews(sourceIssue.Number))
+		reviews, err = github.ReviewsToSlice(m.source.ListReviews(ctx, sourceIssue.Number))
 		if err != nil {
 			return nil, err
 		}
-		reviewComments, err = github.ReviewCommentsToSlice(m.source.ListReviewComments(sourceIssue.Number))
+		reviewComments, err = github.ReviewCommentsToSlice(m.source.ListReviewComments(ctx, sourceIssue.Number))
 		if err != nil {
 			return nil, err
 		}
 	}
-	imp, err := m.buildImport(
-		sourceIssue, sourcePullReq, comments, events,
-		commits, commitDiff, reviews, reviewComments,
-		skipAssignee,
+	issue, err := m.scrubSecrets(
+		sourceIssue, comments, commits, &commitDiff, reviews, reviewComments,
 	)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("[>>] creating a new issue: (original: %s)\n", sourceIssue.HTMLURL)
-	return m.target.Import(imp)
+	var archivedDiff *archivedDiff
+	if m.diffArchive != nil && truncateDiff(commitDiff) != commitDiff {
+		if archivedDiff, err = m.archiveDiff(ctx, sourcePullReq, commitDiff); err != nil {
+			return nil, err
+		}
+	}
+	return m.buildImport(ctx,
+		issue, sourcePullReq, comments, events,
+		commits, localCommits, commitDiff, archivedDiff, missingDiff, reviews, reviewComments, degradations,
+	)
 }
 
-func (m *migrator) waitImportIssue(id int, issue *github.Issue) error {
-	var retry int
-	duration := waitImportIssueInitialDuration
-	for {
-		time.Sleep(duration)
-		if retry > 1 {
-			duration *= 2
-			if duration > 10*time.Second {
-				duration = 10 * time.Second
-			}
+func (m *migrator) waitImportResult(ctx context.Context, result *github.ImportResult, issue *github.Issue) error {
+	res, err := m.waitImportIssue(ctx, result.ID, issue)
+	if err != nil {
+		return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
+	}
+	if err := checkImportedNumber(res, issue, m.targetIssueNumber(issue.Number)); err != nil {
+		return err
+	}
+	m.recordIssueURL(issue)
+	return m.reconcileIssue(ctx, issue)
+}
+
+func (m *migrator) waitImportIssue(ctx context.Context, id int, issue *github.Issue) (*github.ImportResult, error) {
+	ctx = withoutCancel(ctx)
+	for retry := 0; ; retry++ {
+		if err := m.pacing.PollImport(ctx, retry); err != nil {
+			return nil, err
 		}
-		res, err := m.target.GetImport(id)
+		res, err := m.target.GetImport(ctx, id)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		switch res.Status {
 		case "imported":
 			fmt.Printf("[<>] checking status: %s (importing %s)\n", res.Status, issue.HTMLURL)
-			return nil
+			return res, nil
 		case "failed":
 			fmt.Printf("[!!] checking status: %s (importing %s)\n", res.Status, issue.HTMLURL)
-			if len(res.Errors) != 0 {
-				return fmt.Errorf("failed status: %w", res.Errors)
-			}
-			return errors.New("failed status")
+			return nil, importFailedError(res)
 		default:
 			fmt.Printf("[??] checking status: %s (importing %s)\n", res.Status, issue.HTMLURL)
 		}
-		retry++
-		if retry >= 60 {
-			return errors.New("reached maximum retry count")
-		}
 	}
 }
 
+func importFailedError(res *github.ImportResult) error {
+	if len(res.Errors) != 0 {
+		return fmt.Errorf("failed status: %w", res.Errors)
+	}
+	return errors.New("failed status")
+}
+
 func (m *migrator) cacheIssueID(number, id int) {
 	if m.issueIDByNumbers == nil {
 		m.issueIDByNumbers = make(map[int]int)
@@ -187,11 +291,11 @@ func (m *migrator) cacheIssueID(number, id int) {
 	m.issueIDByNumbers[number] = id
 }
 
-func (m *migrator) getTargetIssueID(number int) (int, error) {
+func (m *migrator) getTargetIssueID(ctx context.Context, number int) (int, error) {
 	if id, ok := m.issueIDByNumbers[number]; ok {
 		return id, nil
 	}
-	issue, err := m.target.GetIssue(number)
+	issue, err := m.target.GetIssue(ctx, number)
 	if err != nil {
 		return 0, err
 	}
@@ -1,22 +1,54 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-func (m *migrator) migrateLabels() error {
-	sourceLabels, err := github.LabelsToSlice(m.source.ListLabels())
+// LabelPrefix returns a migrator option to prefix the names of the labels (for
+// example, to distinguish the labels of the repositories merged into one).
+func LabelPrefix(prefix string) Option {
+	return func(m *migrator) {
+		m.labelPrefix = prefix
+	}
+}
+
+// LabelOrigin returns a migrator option to label the imported issues with the
+// full name of the source repository.
+func LabelOrigin() Option {
+	return func(m *migrator) {
+		m.labelOrigin = true
+	}
+}
+
+func (m *migrator) migrateLabels(ctx context.Context) error {
+	sourceLabels, err := github.LabelsToSlice(m.source.ListLabels(ctx))
 	if err != nil {
 		return err
 	}
-	targetLabels, err := github.LabelsToSlice(m.target.ListLabels())
+	targetLabels, err := github.LabelsToSlice(m.target.ListLabels(ctx))
 	if err != nil {
 		return err
 	}
-	for _, sourceLabel := range sourceLabels {
+	labels := make([]*github.Label, 0, len(sourceLabels)+1)
+	for _, l := range sourceLabels {
+		labels = append(labels, &github.Label{
+			Name:        m.targetLabelName(l.Name),
+			Description: l.Description,
+			Color:       l.Color,
+		})
+	}
+	if m.labelOrigin {
+		labels = append(labels, &github.Label{
+			Name:        m.sourceRepo.FullName,
+			Description: "Migrated from " + m.sourceRepo.HTMLURL,
+			Color:       "ededed",
+		})
+	}
+	for _, sourceLabel := range labels {
 		fmt.Printf("[=>] migrating a label: %s\n", sourceLabel.Name)
 		var exists bool
 		for _, targetLabel := range targetLabels {
@@ -24,7 +56,7 @@ func (m *migrator) migrateLabels() error {
 				if sourceLabel.Description != targetLabel.Description ||
 					sourceLabel.Color != targetLabel.Color {
 					fmt.Printf("[|>] updating an existing label: %s\n", targetLabel.Name)
-					if _, err := m.target.UpdateLabel(targetLabel.Name, &github.UpdateLabelParams{
+					if _, err := m.target.UpdateLabel(ctx, targetLabel.Name, &github.UpdateLabelParams{
 						Name:        sourceLabel.Name,
 						Description: sourceLabel.Description,
 						Color:       sourceLabel.Color,
@@ -42,7 +74,7 @@ func (m *migrator) migrateLabels() error {
 			continue
 		}
 		fmt.Printf("[>>] creating a new label: %s\n", sourceLabel.Name)
-		if _, err := m.target.CreateLabel(&github.CreateLabelParams{
+		if _, err := m.target.CreateLabel(ctx, &github.CreateLabelParams{
 			Name:        sourceLabel.Name,
 			Description: sourceLabel.Description,
 			Color:       sourceLabel.Color,
@@ -52,3 +84,7 @@ func (m *migrator) migrateLabels() error {
 	}
 	return nil
 }
+
+func (m *migrator) targetLabelName(name string) string {
+	return m.labelPrefix + name
+}
@@ -0,0 +1,273 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// MergeOrder represents the order of importing the issues on merging the
+// repositories.
+type MergeOrder int
+
+// MergeOrder ...
+const (
+	// MergeOrderCreated interleaves the issues of the sources by the created
+	// dates (this is the default).
+	MergeOrderCreated MergeOrder = iota
+	// MergeOrderSource imports the issues source by source.
+	MergeOrderSource
+)
+
+// MilestoneCollisionPolicy represents how to merge the milestones of the same
+// title in the sources.
+type MilestoneCollisionPolicy int
+
+// MilestoneCollisionPolicy ...
+const (
+	// MilestoneCollisionMerge shares the milestone in the target (this is the
+	// default). The description, state and due date of the last source are used.
+	MilestoneCollisionMerge MilestoneCollisionPolicy = iota
+	// MilestoneCollisionRename suffixes the titles with the source repositories.
+	MilestoneCollisionRename
+	// MilestoneCollisionAbort aborts the merge before migrating anything.
+	MilestoneCollisionAbort
+)
+
+// MergeOption is an option of the merger.
+type MergeOption func(*merger)
+
+// MergeIssueOrder returns a merge option to configure the order of importing
+// the issues.
+func MergeIssueOrder(order MergeOrder) MergeOption {
+	return func(g *merger) {
+		g.order = order
+	}
+}
+
+// MilestoneCollision returns a merge option to configure the policy on the
+// milestones of the same title in the sources.
+func MilestoneCollision(policy MilestoneCollisionPolicy) MergeOption {
+	return func(g *merger) {
+		g.milestoneCollision = policy
+	}
+}
+
+// NewMerger creates a Migrator to merge the source repositories into one target
+// repository (for example, to consolidate the repositories into a monorepo).
+// The migrators should be created by New with the same target repository. The
+// labels, milestones, projects and hooks are merged, and the issues are
+// numbered after the highest number in the target (see OffsetNumbers). The
+// references between the sources are rewritten as well. Note that the imports
+// are not pipelined.
+func NewMerger(migrators []Migrator, opts ...MergeOption) Migrator {
+	g := &merger{}
+	for _, m := range migrators {
+		m := m.(*migrator)
+		m.offsetNumbers = true
+		g.migrators = append(g.migrators, m)
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+type merger struct {
+	migrators          []*migrator
+	order              MergeOrder
+	milestoneCollision MilestoneCollisionPolicy
+	issues             []*mergedIssue
+}
+
+type mergedIssue struct {
+	m     *migrator
+	issue *github.Issue
+}
+
+// Migrate merges the repositories. When the context is canceled, the migration
+// stops after the import in flight is completed.
+func (g *merger) Migrate(ctx context.Context) error {
+	// stop the list producers when returned early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := g.init(ctx); err != nil {
+		return err
+	}
+	for _, f := range []func(*migrator, context.Context) error{
+		(*migrator).migrateLabels,
+		// projects and columns should be imported before issues
+		(*migrator).migrateProjects,
+		// the projects of the other sources should be cached as well
+		(*migrator).cacheTargetProjects,
+		// milestones should be imported before issues
+		(*migrator).migrateMilestones,
+	} {
+		if err := g.each(ctx, f); err != nil {
+			return err
+		}
+	}
+	if err := g.migrateIssues(ctx); err != nil {
+		return err
+	}
+	for _, f := range []func(*migrator, context.Context) error{
+		// pinned issues should be pinned after issues
+		(*migrator).migratePinnedIssues,
+		// projects cards should be imported after issues
+		(*migrator).migrateProjectCards,
+		(*migrator).migrateHooks,
+	} {
+		if err := g.each(ctx, f); err != nil {
+			return err
+		}
+	}
+	// the lists may be cut off on canceled
+	return ctx.Err()
+}
+
+// RetryFailed is not supported on merging, since the placeholder issues are
+// not created.
+func (g *merger) RetryFailed(context.Context, []*Failure) error {
+	return errors.New("retrying the failures is not supported on merging the repositories")
+}
+
+func (g *merger) each(ctx context.Context, f func(*migrator, context.Context) error) error {
+	for _, m := range g.migrators {
+		if err := f(m, ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *merger) init(ctx context.Context) (err error) {
+	if len(g.migrators) == 0 {
+		return errors.New("no repository to merge")
+	}
+	targetRepo, err := g.migrators[0].target.Get(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range g.migrators {
+		if m.sourceRepo, err = m.source.Get(ctx); err != nil {
+			return err
+		}
+	}
+	if err = g.buildTargetNumbers(ctx); err != nil {
+		return err
+	}
+	if err = g.resolveMilestoneTitles(ctx); err != nil {
+		return err
+	}
+	for _, m := range g.migrators {
+		// the references to the other sources are rewritten to the target
+		repoMapping := make(map[string]string, len(m.repoMapping)+len(g.migrators))
+		for k, v := range m.repoMapping {
+			repoMapping[k] = v
+		}
+		for _, n := range g.migrators {
+			if n != m {
+				m.mergedSources = append(m.mergedSources, n)
+				repoMapping[n.sourceRepo.FullName] = targetRepo.FullName
+			}
+		}
+		m.repoMapping = repoMapping
+		if err = m.init(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTargetNumbers lists the issues of the sources in the order of importing,
+// and numbers them sequentially.
+func (g *merger) buildTargetNumbers(ctx context.Context) error {
+	numbering, err := newTargetNumbering(ctx, g.migrators[0].target)
+	if err != nil {
+		return err
+	}
+	for _, m := range g.migrators {
+		issues, err := github.IssuesToSlice(m.source.ListIssues(ctx))
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			g.issues = append(g.issues, &mergedIssue{m, issue})
+		}
+		m.targetNumbers = make(map[int]int, len(issues))
+	}
+	if g.order == MergeOrderCreated {
+		sort.SliceStable(g.issues, func(i, j int) bool {
+			return g.issues[i].issue.CreatedAt < g.issues[j].issue.CreatedAt
+		})
+	}
+	for _, x := range g.issues {
+		x.m.targetNumbers[x.issue.Number] = numbering.next(x.issue)
+	}
+	return nil
+}
+
+// resolveMilestoneTitles resolves the titles of the milestones in the target,
+// according to the policy on the milestones of the same title in the sources.
+func (g *merger) resolveMilestoneTitles(ctx context.Context) error {
+	var titles []string
+	sources := make(map[string][]*migrator)
+	for _, m := range g.migrators {
+		milestones, err := github.MilestonesToSlice(
+			m.source.ListMilestones(ctx, &github.ListMilestonesParams{
+				State: github.ListMilestonesParamStateAll,
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		for _, l := range milestones {
+			if _, ok := sources[l.Title]; !ok {
+				titles = append(titles, l.Title)
+			}
+			sources[l.Title] = append(sources[l.Title], m)
+		}
+	}
+	for _, title := range titles {
+		ms := sources[title]
+		if len(ms) < 2 {
+			continue
+		}
+		switch g.milestoneCollision {
+		case MilestoneCollisionRename:
+			for _, m := range ms {
+				if m.milestoneTitles == nil {
+					m.milestoneTitles = make(map[string]string)
+				}
+				m.milestoneTitles[title] = fmt.Sprintf("%s (%s)", title, m.sourceRepo.FullName)
+			}
+		case MilestoneCollisionAbort:
+			return fmt.Errorf(
+				"milestone collision: %q exists in %s and %s",
+				title, ms[0].sourceRepo.FullName, ms[1].sourceRepo.FullName,
+			)
+		}
+	}
+	return nil
+}
+
+func (g *merger) migrateIssues(ctx context.Context) error {
+	// stop the list producer when returned early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	targetIssuesBuffer := newIssuesBuffer(g.migrators[0].target.ListIssues(ctx))
+	for _, x := range g.issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := x.m.migrateAndWaitIssue(ctx, x.issue, targetIssuesBuffer, false); err != nil {
+			if err := x.m.handleIssueFailure(ctx, x.issue, err); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,233 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestMerger(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		order              MergeOrder
+		milestoneCollision MilestoneCollisionPolicy
+		titles             []string
+		milestones         []string
+		err                string
+	}{
+		{
+			name:   "created order",
+			order:  MergeOrderCreated,
+			titles: []string{"Source 1 issue 1", "Source 2 issue 1", "Source 1 issue 2"},
+			milestones: []string{
+				"v1.0", "v2.0",
+			},
+		},
+		{
+			name:               "source order",
+			order:              MergeOrderSource,
+			milestoneCollision: MilestoneCollisionRename,
+			titles:             []string{"Source 1 issue 1", "Source 1 issue 2", "Source 2 issue 1"},
+			milestones: []string{
+				"v1.0 (example/source1)", "v1.0 (example/source2)", "v2.0",
+			},
+		},
+		{
+			name:               "milestone collision",
+			milestoneCollision: MilestoneCollisionAbort,
+			err:                `milestone collision: "v1.0" exists in example/source1 and example/source2`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli, imports, labels, milestones := newMergerTestClient()
+			source1 := repo.New(cli, "example/source1")
+			source2 := repo.New(cli, "example/source2")
+			target := repo.New(cli, "example/target")
+			mapping := NewURLMapping()
+			m := NewMerger([]Migrator{
+				New(source1, target, nil, LabelPrefix("s1: "), LabelOrigin(), RecordURLs(mapping)),
+				New(source2, target, nil, LabelOrigin(), RecordURLs(mapping)),
+			}, MergeIssueOrder(tc.order), MilestoneCollision(tc.milestoneCollision))
+			err := m.Migrate(context.Background())
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Len(t, *imports, 0)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"bug", "example/source1", "example/source2", "s1: bug"}, *labels)
+			assert.Equal(t, tc.milestones, *milestones)
+			require.Len(t, *imports, 3)
+			titles := make([]string, len(*imports))
+			for i, imp := range *imports {
+				titles[i] = imp.Issue.Title
+			}
+			assert.Equal(t, tc.titles, titles)
+
+			numbers := map[string]int{}
+			for i, title := range tc.titles {
+				numbers[title] = i + 2
+			}
+			urls := map[string]string{}
+			for _, e := range mapping.entries {
+				urls[e.SourceURL] = e.TargetURL
+			}
+			assert.Equal(t, map[string]string{
+				"http://localhost/example/source1": "http://localhost/example/target",
+				"http://localhost/example/source2": "http://localhost/example/target",
+				"http://localhost/example/source1/issues/1": fmt.Sprintf(
+					"http://localhost/example/target/issues/%d", numbers["Source 1 issue 1"]),
+				"http://localhost/example/source1/issues/2": fmt.Sprintf(
+					"http://localhost/example/target/issues/%d", numbers["Source 1 issue 2"]),
+				"http://localhost/example/source2/issues/1": fmt.Sprintf(
+					"http://localhost/example/target/issues/%d", numbers["Source 2 issue 1"]),
+			}, urls)
+			for _, imp := range *imports {
+				switch imp.Issue.Title {
+				case "Source 1 issue 1":
+					assert.Equal(t, []string{"s1: bug", "example/source1"}, imp.Issue.Labels)
+					assert.NotZero(t, imp.Issue.Milestone)
+					assert.Contains(t, imp.Issue.Body, fmt.Sprintf(
+						"Depends on #%d and example/target#%d.",
+						numbers["Source 1 issue 2"], numbers["Source 2 issue 1"],
+					))
+				case "Source 2 issue 1":
+					assert.Equal(t, []string{"bug", "example/source2"}, imp.Issue.Labels)
+					assert.Contains(t, imp.Issue.Body, fmt.Sprintf(
+						"Fixed in http://localhost/example/target/issues/%d and #%d.",
+						numbers["Source 1 issue 2"], numbers["Source 2 issue 1"],
+					))
+				}
+			}
+		})
+	}
+}
+
+func TestMergerRetryFailed(t *testing.T) {
+	err := NewMerger(nil).RetryFailed(context.Background(), nil)
+	assert.EqualError(t, err, "retrying the failures is not supported on merging the repositories")
+}
+
+func newMergerTestClient() (github.Client, *[]*github.Import, *[]string, *[]string) {
+	issues := map[string][]*github.Issue{
+		"example/source1": {
+			{
+				Number:    1,
+				Title:     "Source 1 issue 1",
+				Body:      "Depends on #2 and example/source2#1.",
+				HTMLURL:   "http://localhost/example/source1/issues/1",
+				User:      &github.User{Login: "sample-user-1"},
+				CreatedAt: "2019-11-10T12:00:00Z",
+				Labels:    []*github.Label{{Name: "bug"}},
+				Milestone: &github.Milestone{Title: "v1.0"},
+			},
+			{
+				Number:    2,
+				Title:     "Source 1 issue 2",
+				HTMLURL:   "http://localhost/example/source1/issues/2",
+				User:      &github.User{Login: "sample-user-1"},
+				CreatedAt: "2019-11-12T12:00:00Z",
+			},
+		},
+		"example/source2": {
+			{
+				Number:    1,
+				Title:     "Source 2 issue 1",
+				Body:      "Fixed in http://localhost/example/source1/issues/2 and #1.",
+				HTMLURL:   "http://localhost/example/source2/issues/1",
+				User:      &github.User{Login: "sample-user-2"},
+				CreatedAt: "2019-11-11T12:00:00Z",
+				Labels:    []*github.Label{{Name: "bug"}},
+			},
+		},
+		"example/target": {
+			{
+				Number:  1,
+				Title:   "Existing issue",
+				HTMLURL: "http://localhost/example/target/issues/1",
+			},
+		},
+	}
+	sourceMilestones := map[string][]*github.Milestone{
+		"example/source1": {{Number: 1, Title: "v1.0"}},
+		"example/source2": {{Number: 1, Title: "v1.0"}, {Number: 3, Title: "v2.0"}},
+	}
+	var imports []*github.Import
+	var labels, milestones []string
+	var targetMilestones []*github.Milestone
+	cli := github.NewMockClient(
+		github.MockGetRepo(func(path string) (*github.Repo, error) {
+			return &github.Repo{FullName: path, HTMLURL: "http://localhost/" + path}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice(nil)
+		}),
+		github.MockGetUser(func(string) (*github.User, error) {
+			return nil, errors.New("Not Found")
+		}),
+		github.MockListIssues(func(path string, _ *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice(issues[path])
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice(nil)
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+		github.MockListTimeline(func(string, int) github.Events {
+			return github.EventsFromSlice(nil)
+		}),
+		github.MockListLabels(func(path string) github.Labels {
+			if path == "example/target" {
+				return github.LabelsFromSlice(nil)
+			}
+			return github.LabelsFromSlice([]*github.Label{{Name: "bug", Color: "ff0000"}})
+		}),
+		github.MockCreateLabel(func(path string, params *github.CreateLabelParams) (*github.Label, error) {
+			labels = append(labels, params.Name)
+			sort.Strings(labels)
+			return &github.Label{Name: params.Name}, nil
+		}),
+		github.MockListProjects(func(string, *github.ListProjectsParams) github.Projects {
+			return github.ProjectsFromSlice(nil)
+		}),
+		github.MockListMilestones(func(path string, _ *github.ListMilestonesParams) github.Milestones {
+			if path == "example/target" {
+				return github.MilestonesFromSlice(targetMilestones)
+			}
+			return github.MilestonesFromSlice(sourceMilestones[path])
+		}),
+		github.MockCreateMilestone(func(_ string, params *github.CreateMilestoneParams) (*github.Milestone, error) {
+			milestones = append(milestones, params.Title)
+			sort.Strings(milestones)
+			l := &github.Milestone{Number: len(targetMilestones) + 1, Title: params.Title}
+			targetMilestones = append(targetMilestones, l)
+			return l, nil
+		}),
+		github.MockImport(func(_ string, imp *github.Import) (*github.ImportResult, error) {
+			imports = append(imports, imp)
+			return &github.ImportResult{ID: len(imports), Status: "pending"}, nil
+		}),
+		github.MockGetImport(func(_ string, id int) (*github.ImportResult, error) {
+			return &github.ImportResult{
+				ID: id, Status: "imported",
+				IssueURL: fmt.Sprintf("http://localhost/api/v3/repos/example/target/issues/%d", id+1),
+			}, nil
+		}),
+		github.MockListPinnedIssues(func(string) ([]int, error) {
+			return nil, nil
+		}),
+		github.MockListHooks(func(string) github.Hooks {
+			return github.HooksFromSlice(nil)
+		}),
+	)
+	return cli, &imports, &labels, &milestones
+}
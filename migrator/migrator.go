@@ -1,6 +1,8 @@
 package migrator
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
@@ -9,74 +11,163 @@ import (
 
 // Migrator represents a GitHub migrator.
 type Migrator interface {
-	Migrate() error
+	Migrate(context.Context) error
+	RetryFailed(context.Context, []*Failure) error
 }
 
 // New creates a new Migrator.
-func New(source, target *repo.Repo, userMapping map[string]string) Migrator {
-	return &migrator{source: source, target: target, userMapping: userMapping}
+func New(source, target *repo.Repo, userMapping map[string]string, opts ...Option) Migrator {
+	m := &migrator{
+		source: source, target: target, userMapping: userMapping,
+		diffProvider: &apiDiffProvider{repo: source},
+		pacing:       defaultPacing(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
+// Option is an option of Migrator.
+type Option func(*migrator)
+
 type migrator struct {
 	source, target         *repo.Repo
 	userMapping            map[string]string
+	repoMapping            map[string]string
+	commitMapper           *commitMapper
 	sourceRepo, targetRepo *github.Repo
 	commentFilters         commentFilters
+	userFilter             commentFilter
+	customFilters          commentFilters
+	secretScanner          *secretScanner
+	diffProvider           DiffProvider
+	diffFallbacks          []DiffProvider
+	skipMissingDiffs       bool
+	diffArchive            DiffArchive
+	errorPolicy            ErrorPolicy
+	failureEncoder         *json.Encoder
+	importPipelineSize     int
+	offsetNumbers          bool
+	mergedSources          []*migrator
+	labelPrefix            string
+	labelOrigin            bool
+	milestoneTitles        map[string]string
+	pacing                 *github.Pacing
+	version                string
+	reportEncoder          *json.Encoder
+	urlMapping             *URLMapping
+	mentionFilter          commentFilter
+	suppressMentions       bool
+	mentionAllowlist       []string
 	targetMembers          []*github.Member
 	targetProjects         []*github.Project
 	projectByIDs           map[int]*github.Project
 	userByNames            map[string]*github.User
 	errorUserByNames       map[string]error
 	issueIDByNumbers       map[int]int
+	targetNumbers          map[int]int
+	importedAssignees      map[int]string
+	archivedDiffs          map[int]*archivedDiff
 	milestoneByTitle       map[string]*github.Milestone
 }
 
-// Migrate the repository.
-func (m *migrator) Migrate() (err error) {
-	if m.sourceRepo, err = m.source.Get(); err != nil {
+// Migrate the repository. When the context is canceled, the migration stops
+// after the imports in flight are completed.
+func (m *migrator) Migrate(ctx context.Context) (err error) {
+	// stop the list producers when returned early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err = m.init(ctx); err != nil {
 		return err
 	}
-	if m.targetRepo, err = m.target.Get(); err != nil {
+	if err = m.migrateRepo(ctx); err != nil {
 		return err
 	}
-	m.commentFilters = newCommentFilters(
-		newRepoURLFilter(m.sourceRepo, m.targetRepo),
-		newUserMappingFilter(m.userMapping, m.targetRepo),
-	)
-	if m.targetMembers, err = github.MembersToSlice(m.target.ListMembers()); err != nil {
+	if err = m.migrateLabels(ctx); err != nil {
 		return err
 	}
-	if err = m.migrateRepo(); err != nil {
+	// projects and columns should be imported before issues
+	if err = m.migrateProjects(ctx); err != nil {
 		return err
 	}
-	if err = m.migrateLabels(); err != nil {
+	if err = m.cacheTargetProjects(ctx); err != nil {
 		return err
 	}
-	// projects and columns should be imported before issues
-	if err = m.migrateProjects(); err != nil {
+	// milestones should be imported before issues
+	if err = m.migrateMilestones(ctx); err != nil {
 		return err
 	}
-	if projects, err := github.ProjectsToSlice(m.target.ListProjects()); err != nil {
-		if !strings.Contains(err.Error(), "Projects are disabled for this repository") {
-			return err
-		}
-		m.targetProjects = []*github.Project{}
-	} else {
-		m.targetProjects = projects
-	}
-	// milestones should be imported before issues
-	if err = m.migrateMilestones(); err != nil {
+	if err = m.migrateIssues(ctx); err != nil {
 		return err
 	}
-	if err = m.migrateIssues(); err != nil {
+	// pinned issues should be pinned after issues
+	if err = m.migratePinnedIssues(ctx); err != nil {
 		return err
 	}
 	// projects cards should be imported after issues
-	if err = m.migrateProjectCards(); err != nil {
+	if err = m.migrateProjectCards(ctx); err != nil {
 		return err
 	}
-	if err = m.migrateHooks(); err != nil {
+	if err = m.migrateHooks(ctx); err != nil {
 		return err
 	}
+	// the lists may be cut off on canceled
+	return ctx.Err()
+}
+
+func (m *migrator) init(ctx context.Context) (err error) {
+	if m.sourceRepo, err = m.source.Get(ctx); err != nil {
+		return err
+	}
+	if m.targetRepo, err = m.target.Get(ctx); err != nil {
+		return err
+	}
+	// the numbers are built by the merger on merging the repositories
+	if m.offsetNumbers && m.targetNumbers == nil {
+		if err = m.buildTargetNumbers(ctx); err != nil {
+			return err
+		}
+	}
+	m.commentFilters = newCommentFilters(
+		newIssueNumberFilter(m.targetNumbers, m.sourceRepo, m.sourceRepo, m.targetRepo),
+	)
+	var numberedRepos []string
+	if m.targetNumbers != nil {
+		numberedRepos = append(numberedRepos, m.sourceRepo.FullName)
+	}
+	for _, n := range m.mergedSources {
+		m.commentFilters = append(m.commentFilters,
+			newIssueNumberFilter(n.targetNumbers, n.sourceRepo, m.sourceRepo, m.targetRepo))
+		if n.targetNumbers != nil {
+			numberedRepos = append(numberedRepos, n.sourceRepo.FullName)
+		}
+	}
+	m.userFilter = newUserMappingFilter(m.userMapping, m.targetRepo)
+	m.commentFilters = append(m.commentFilters,
+		newRepoURLFilter(m.sourceRepo, m.targetRepo, m.targetNumbers != nil),
+		newRepoMappingFilter(m.repoMapping, m.sourceRepo, m.targetRepo, numberedRepos),
+		newCommitMappingFilter(m.commitMapper),
+		m.userFilter,
+	)
+	m.commentFilters = append(m.commentFilters, m.customFilters...)
+	m.recordURL("repository", m.sourceRepo.HTMLURL, m.targetRepo.HTMLURL)
+	if m.suppressMentions {
+		m.mentionFilter = newMentionFilter(m.mentionAllowlist, m.targetRepo)
+		m.commentFilters = append(m.commentFilters, m.mentionFilter)
+	}
+	m.targetMembers, err = github.MembersToSlice(m.target.ListMembers(ctx))
+	return err
+}
+
+func (m *migrator) cacheTargetProjects(ctx context.Context) error {
+	projects, err := github.ProjectsToSlice(m.target.ListProjects(ctx))
+	if err != nil {
+		if !strings.Contains(err.Error(), "Projects are disabled for this repository") {
+			return err
+		}
+		projects = []*github.Project{}
+	}
+	m.targetProjects = projects
 	return nil
 }
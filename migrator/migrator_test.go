@@ -1,6 +1,7 @@
 package migrator
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,10 +17,13 @@ import (
 )
 
 func init() {
-	beforeImportIssueDuration = 0
-	waitImportIssueInitialDuration = 0
-	waitProjectColumnDuration = 0
-	waitProjectCardDuration = 0
+	defaultPacing = func() *github.Pacing {
+		return github.NewPacing(
+			github.PacingBeforeImport(0),
+			github.PacingPollImport(0, 0, 60),
+			github.PacingProjects(0, 0),
+		)
+	}
 }
 
 type testRepo struct {
@@ -34,13 +38,18 @@ type testRepo struct {
 		*github.PullReq
 		Comments       []*github.Comment       `json:"comments"`
 		Events         []*github.Event         `json:"events"`
+		Timeline       []*github.Event         `json:"timeline"`
 		Commits        []*github.Commit        `json:"commit_details"`
 		Reviews        []*github.Review        `json:"reviews"`
 		ReviewComments []*github.ReviewComment `json:"review_comments"`
 	}
-	Compare  map[string]string
-	Imports  []*github.Import `json:"imports"`
-	Projects []*struct {
+	PinnedIssues []int          `json:"pinned_issues"`
+	CloseIssues  map[int]string `json:"close_issues"`
+	LockIssues   map[int]string `json:"lock_issues"`
+	PinIssues    []int          `json:"pin_issues"`
+	Compare      map[string]string
+	Imports      []*github.Import `json:"imports"`
+	Projects     []*struct {
 		*github.Project
 		Columns []*testProjectColumn `json:"columns"`
 	} `json:"projects"`
@@ -139,6 +148,28 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			}
 			panic(fmt.Sprintf("unexpected issue number: %d", issueNumber))
 		}),
+		github.MockCloseIssue(func(_ string, issueNumber int, stateReason string) (*github.Issue, error) {
+			assert.True(t, isTarget)
+			assert.Equal(t, r.CloseIssues[issueNumber], stateReason)
+			return nil, nil
+		}),
+		github.MockLockIssue(func(_ string, issueNumber int, lockReason string) error {
+			assert.True(t, isTarget)
+			assert.Equal(t, r.LockIssues[issueNumber], lockReason)
+			return nil
+		}),
+		github.MockListPinnedIssues(func(string) ([]int, error) {
+			return r.PinnedIssues, nil
+		}),
+		github.MockPinIssue((func(i int) func(string, int) error {
+			return func(_ string, issueNumber int) error {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.PinIssues), i)
+				assert.Equal(t, r.PinIssues[i], issueNumber)
+				return nil
+			}
+		})(0)),
 		github.MockListComments(func(_ string, issueNumber int) github.Comments {
 			assert.True(t, !isTarget)
 			for _, s := range r.Issues {
@@ -157,6 +188,15 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			}
 			panic(fmt.Sprintf("unexpected issue number: %d", issueNumber))
 		}),
+		github.MockListTimeline(func(_ string, issueNumber int) github.Events {
+			assert.True(t, !isTarget)
+			for _, s := range r.Issues {
+				if s.Issue.Number == issueNumber {
+					return github.EventsFromSlice(s.Timeline)
+				}
+			}
+			panic(fmt.Sprintf("unexpected issue number: %d", issueNumber))
+		}),
 
 		github.MockGetPullReq(func(_ string, pullNumber int) (*github.PullReq, error) {
 			assert.True(t, !isTarget)
@@ -353,7 +393,7 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 				defer func() { i++ }()
 				assert.True(t, isTarget)
 				require.Greater(t, len(r.Imports), i)
-				assert.Equal(t, r.Imports[i], x)
+				assert.Equal(t, r.Imports[i], stripProvenances(t, x))
 				return &github.ImportResult{
 					ID:     12345,
 					Status: "pending",
@@ -373,6 +413,26 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 	), r.Repo.FullName)
 }
 
+// stripProvenances verifies the provenance metadata of the issue and comments,
+// and strips them to compare with the expected bodies.
+func stripProvenances(t *testing.T, x *github.Import) *github.Import {
+	strip := func(body string) string {
+		p, rest, ok := parseProvenance(body)
+		if assert.True(t, ok, body) {
+			assert.Equal(t, "github-migrator", p.Migrator)
+			assert.Equal(t, contentHash(rest), p.ContentHash)
+		}
+		return rest
+	}
+	issue := *x.Issue
+	issue.Body = strip(issue.Body)
+	comments := make([]*github.ImportComment, len(x.Comments))
+	for i, c := range x.Comments {
+		comments[i] = &github.ImportComment{Body: strip(c.Body), CreatedAt: c.CreatedAt}
+	}
+	return &github.Import{Issue: &issue, Comments: comments}
+}
+
 func TestMigratorMigrate(t *testing.T) {
 	f, err := os.Open("test.yaml")
 	require.NoError(t, err)
@@ -391,7 +451,7 @@ func TestMigratorMigrate(t *testing.T) {
 			source := tc.Source.build(t, false)
 			target := tc.Target.build(t, true)
 			migrator := New(source, target, tc.UserMapping)
-			assert.Nil(t, migrator.Migrate())
+			assert.Nil(t, migrator.Migrate(context.Background()))
 		})
 	}
 }
@@ -1,15 +1,16 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-func (m *migrator) migrateMilestones() error {
+func (m *migrator) migrateMilestones(ctx context.Context) error {
 	sourceMilestones, err := github.MilestonesToSlice(
-		m.source.ListMilestones(&github.ListMilestonesParams{
+		m.source.ListMilestones(ctx, &github.ListMilestonesParams{
 			State: github.ListMilestonesParamStateAll,
 		}),
 	)
@@ -17,7 +18,7 @@ func (m *migrator) migrateMilestones() error {
 		return err
 	}
 	targetMilestones, err := github.MilestonesToSlice(
-		m.target.ListMilestones(&github.ListMilestonesParams{
+		m.target.ListMilestones(ctx, &github.ListMilestonesParams{
 			State: github.ListMilestonesParamStateAll,
 		}),
 	)
@@ -32,9 +33,11 @@ func (m *migrator) migrateMilestones() error {
 	}
 	var deletedMilestones []int
 	for _, l := range sourceMilestones {
-		fmt.Printf("[=>] migrating a milestone: %s\n", l.Title)
-		for l.Number > largestMilestoneNumber+1 {
-			n, err := m.target.CreateMilestone(&github.CreateMilestoneParams{
+		title := m.targetMilestoneTitle(l.Title)
+		fmt.Printf("[=>] migrating a milestone: %s\n", title)
+		// the numbers are not aligned in the non-empty target (see OffsetNumbers)
+		for !m.offsetNumbers && l.Number > largestMilestoneNumber+1 {
+			n, err := m.target.CreateMilestone(ctx, &github.CreateMilestoneParams{
 				Title: fmt.Sprintf("[Deleted milestone %d]", largestMilestoneNumber+1), // must be unique
 				State: github.MilestoneStateClosed,
 			})
@@ -44,22 +47,27 @@ func (m *migrator) migrateMilestones() error {
 			largestMilestoneNumber = n.Number
 			deletedMilestones = append(deletedMilestones, n.Number)
 		}
-		n := lookupMilestone(targetMilestones, l)
+		n := lookupMilestone(targetMilestones, title)
+		description, err := m.filterText(l.Description, l.HTMLURL, "milestone description")
+		if err != nil {
+			return err
+		}
 		if n == nil {
-			fmt.Printf("[>>] creating a new milestone: %s\n", l.Title)
-			if n, err = m.target.CreateMilestone(&github.CreateMilestoneParams{
-				Title: l.Title, Description: l.Description,
+			fmt.Printf("[>>] creating a new milestone: %s\n", title)
+			if n, err = m.target.CreateMilestone(ctx, &github.CreateMilestoneParams{
+				Title: title, Description: description,
 				State: l.State, DueOn: l.DueOn,
 			}); err != nil {
 				return err
 			}
 			largestMilestoneNumber = n.Number
 		}
-		if l.Description != n.Description || l.State != n.State || normalizeTimeToPST(l.DueOn) != normalizeTimeToPST(n.DueOn) {
-			fmt.Printf("[|>] updating an existing milestone: %s\n", l.Title)
-			if _, err = m.target.UpdateMilestone(n.Number, &github.UpdateMilestoneParams{
-				Title:       l.Title,
-				Description: l.Description,
+		m.recordURL("milestone", l.HTMLURL, n.HTMLURL)
+		if description != n.Description || l.State != n.State || normalizeTimeToPST(l.DueOn) != normalizeTimeToPST(n.DueOn) {
+			fmt.Printf("[|>] updating an existing milestone: %s\n", title)
+			if _, err = m.target.UpdateMilestone(ctx, n.Number, &github.UpdateMilestoneParams{
+				Title:       title,
+				Description: description,
 				State:       l.State,
 				DueOn:       l.DueOn,
 			}); err != nil {
@@ -68,12 +76,16 @@ func (m *migrator) migrateMilestones() error {
 		}
 	}
 	for _, number := range deletedMilestones {
-		if err := m.target.DeleteMilestone(number); err != nil {
+		if err := m.target.DeleteMilestone(ctx, number); err != nil {
 			return err
 		}
 	}
-	targetMilestones, err = github.MilestonesToSlice(
-		m.target.ListMilestones(&github.ListMilestonesParams{
+	return m.cacheMilestones(ctx)
+}
+
+func (m *migrator) cacheMilestones(ctx context.Context) error {
+	targetMilestones, err := github.MilestonesToSlice(
+		m.target.ListMilestones(ctx, &github.ListMilestonesParams{
 			State: github.ListMilestonesParamStateAll,
 		}),
 	)
@@ -87,15 +99,24 @@ func (m *migrator) migrateMilestones() error {
 	return nil
 }
 
-func lookupMilestone(ps []*github.Milestone, l *github.Milestone) *github.Milestone {
+func lookupMilestone(ps []*github.Milestone, title string) *github.Milestone {
 	for _, n := range ps {
-		if l.Title == n.Title {
+		if title == n.Title {
 			return n
 		}
 	}
 	return nil
 }
 
+// targetMilestoneTitle returns the title of the milestone in the target, which
+// can be renamed on merging the repositories (see MilestoneCollision).
+func (m *migrator) targetMilestoneTitle(title string) string {
+	if t, ok := m.milestoneTitles[title]; ok {
+		return t
+	}
+	return title
+}
+
 // https://github.community/t5/How-to-use-Git-and-GitHub/Milestone-quot-Due-On-quot-field-defaults-to-7-00-when-set-by-v3/m-p/6922
 func normalizeTimeToPST(s string) string {
 	t, err := time.Parse(time.RFC3339, s)
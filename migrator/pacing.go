@@ -0,0 +1,15 @@
+package migrator
+
+import "github.com/itchyny/github-migrator/github"
+
+// Pace returns a migrator option to set the pacing policy of the migration.
+// The policy should be shared with the clients to adapt to their responses.
+func Pace(p *github.Pacing) Option {
+	return func(m *migrator) {
+		m.pacing = p
+	}
+}
+
+var defaultPacing = func() *github.Pacing {
+	return github.NewPacing()
+}
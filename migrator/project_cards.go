@@ -1,18 +1,16 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
-	"time"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-var waitProjectCardDuration = 100 * time.Millisecond
-
-func (m *migrator) migrateProjectCards() error {
-	sourceProjects, err := github.ProjectsToSlice(m.source.ListProjects())
+func (m *migrator) migrateProjectCards(ctx context.Context) error {
+	sourceProjects, err := github.ProjectsToSlice(m.source.ListProjects(ctx))
 	if err != nil {
 		if strings.Contains(err.Error(), "Projects are disabled for this repository") {
 			return nil // do nothing
@@ -22,7 +20,7 @@ func (m *migrator) migrateProjectCards() error {
 	if len(sourceProjects) == 0 {
 		return nil
 	}
-	targetProjects, err := github.ProjectsToSlice(m.target.ListProjects())
+	targetProjects, err := github.ProjectsToSlice(m.target.ListProjects(ctx))
 	if err != nil {
 		return err
 	}
@@ -32,17 +30,17 @@ func (m *migrator) migrateProjectCards() error {
 		if q == nil {
 			return fmt.Errorf("project not found: %s", p.Name)
 		}
-		if err := m.migrateProjectCardsInProject(p.ID, q.ID); err != nil {
+		if err := m.migrateProjectCardsInProject(ctx, p.HTMLURL, p.ID, q.ID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (m *migrator) migrateProjectCardsInProject(sourceID, targetID int) error {
-	sourceColumns := m.source.ListProjectColumns(sourceID)
+func (m *migrator) migrateProjectCardsInProject(ctx context.Context, projectURL string, sourceID, targetID int) error {
+	sourceColumns := m.source.ListProjectColumns(ctx, sourceID)
 	targetColumns, err := github.ProjectColumnsToSlice(
-		m.target.ListProjectColumns(targetID),
+		m.target.ListProjectColumns(ctx, targetID),
 	)
 	if err != nil {
 		return err
@@ -60,36 +58,43 @@ func (m *migrator) migrateProjectCardsInProject(sourceID, targetID int) error {
 		if d == nil {
 			return fmt.Errorf("project card not found: %s", c.Name)
 		}
-		if err := m.migrateProjectCardsInColumn(c.ID, d.ID); err != nil {
+		if err := m.migrateProjectCardsInColumn(ctx, projectURL, c.ID, d.ID); err != nil {
 			return err
 		}
 	}
 }
 
-func (m *migrator) migrateProjectCardsInColumn(sourceID, targetID int) error {
+func (m *migrator) migrateProjectCardsInColumn(ctx context.Context, projectURL string, sourceID, targetID int) error {
 	sourceCards, err := github.ProjectCardsToSlice(
-		m.source.ListProjectCards(sourceID),
+		m.source.ListProjectCards(ctx, sourceID),
 	)
 	if err != nil {
 		return err
 	}
 	targetCards, err := github.ProjectCardsToSlice(
-		m.target.ListProjectCards(targetID),
+		m.target.ListProjectCards(ctx, targetID),
 	)
 	if err != nil {
 		return err
 	}
 	reverseProjectCards(sourceCards)
 	for _, c := range sourceCards {
-		fmt.Printf("[=>] migrating a card: %s\n", m.getCardInfo(c))
-		if lookupProjectCard(targetCards, c) != nil {
-			fmt.Printf("[--] skipping: %s (already exists)\n", m.getCardInfo(c))
+		issueNumber := c.GetIssueNumber()
+		if issueNumber > 0 {
+			if issueNumber = m.targetIssueNumber(issueNumber); issueNumber == 0 {
+				fmt.Printf("[--] skipping: %s (not migrated)\n", c.ContentURL)
+				continue
+			}
+		}
+		fmt.Printf("[=>] migrating a card: %s\n", m.getCardInfo(c, issueNumber))
+		if lookupProjectCard(targetCards, c, issueNumber) != nil {
+			fmt.Printf("[--] skipping: %s (already exists)\n", m.getCardInfo(c, issueNumber))
 			continue
 		}
-		fmt.Printf("[>>] creating a new card: %s\n", m.getCardInfo(c))
+		fmt.Printf("[>>] creating a new card: %s\n", m.getCardInfo(c, issueNumber))
 		var params *github.CreateProjectCardParams
-		if issueNumber := c.GetIssueNumber(); issueNumber > 0 {
-			id, err := m.getTargetIssueID(issueNumber)
+		if issueNumber > 0 {
+			id, err := m.getTargetIssueID(ctx, issueNumber)
 			if err != nil {
 				return err
 			}
@@ -98,21 +103,27 @@ func (m *migrator) migrateProjectCardsInColumn(sourceID, targetID int) error {
 				ContentType: github.ProjectCardContentTypeIssue,
 			}
 		} else {
-			params = &github.CreateProjectCardParams{
-				Note: m.commentFilters.apply(c.Note),
+			note, err := m.filterText(c.Note, projectURL, "project note")
+			if err != nil {
+				return err
 			}
+			params = &github.CreateProjectCardParams{Note: note}
+		}
+		if _, err := m.target.CreateProjectCard(ctx, targetID, params); err != nil {
+			return err
 		}
-		if _, err := m.target.CreateProjectCard(targetID, params); err != nil {
+		if err := m.pacing.AfterProjectCard(ctx); err != nil {
 			return err
 		}
-		time.Sleep(waitProjectCardDuration)
 	}
 	return nil
 }
 
-func lookupProjectCard(cs []*github.ProjectCard, c *github.ProjectCard) *github.ProjectCard {
+// lookupProjectCard looks up the card in the target project. The issue number
+// of the card should be the number in the target repository.
+func lookupProjectCard(cs []*github.ProjectCard, c *github.ProjectCard, issueNumber int) *github.ProjectCard {
 	for _, d := range cs {
-		if c.Note != "" && c.Note == d.Note || c.GetIssueNumber() == d.GetIssueNumber() {
+		if c.Note != "" && c.Note == d.Note || issueNumber == d.GetIssueNumber() {
 			return d
 		}
 	}
@@ -125,8 +136,8 @@ func reverseProjectCards(cs []*github.ProjectCard) {
 	}
 }
 
-func (m *migrator) getCardInfo(c *github.ProjectCard) string {
-	if issueNumber := c.GetIssueNumber(); issueNumber > 0 {
+func (m *migrator) getCardInfo(c *github.ProjectCard, issueNumber int) string {
+	if issueNumber > 0 {
 		return fmt.Sprintf("%s/issues/%d", m.targetRepo.FullName, issueNumber)
 	}
 	xs := strings.Split(c.Note, "\n")
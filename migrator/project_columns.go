@@ -1,19 +1,17 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"time"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-var waitProjectColumnDuration = 100 * time.Millisecond
-
-func (m *migrator) migrateProjectColumns(sourceID, targetID int) error {
-	sourceColumns := m.source.ListProjectColumns(sourceID)
+func (m *migrator) migrateProjectColumns(ctx context.Context, sourceID, targetID int) error {
+	sourceColumns := m.source.ListProjectColumns(ctx, sourceID)
 	targetColumns, err := github.ProjectColumnsToSlice(
-		m.target.ListProjectColumns(targetID),
+		m.target.ListProjectColumns(ctx, targetID),
 	)
 	if err != nil {
 		return err
@@ -30,11 +28,13 @@ func (m *migrator) migrateProjectColumns(sourceID, targetID int) error {
 		d := lookupProjectColumn(targetColumns, c)
 		if d == nil {
 			fmt.Printf("[>>] creating a new project column: %s\n", c.Name)
-			if _, err = m.target.CreateProjectColumn(targetID, c.Name); err != nil {
+			if _, err = m.target.CreateProjectColumn(ctx, targetID, c.Name); err != nil {
 				return err
 			}
 		}
-		time.Sleep(waitProjectColumnDuration)
+		if err := m.pacing.AfterProjectColumn(ctx); err != nil {
+			return err
+		}
 	}
 }
 
@@ -1,14 +1,15 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-func (m *migrator) migrateProjects() error {
-	sourceProjects, err := github.ProjectsToSlice(m.source.ListProjects())
+func (m *migrator) migrateProjects(ctx context.Context) error {
+	sourceProjects, err := github.ProjectsToSlice(m.source.ListProjects(ctx))
 	if err != nil {
 		if strings.Contains(err.Error(), "Projects are disabled for this repository") {
 			return nil // do nothing
@@ -18,7 +19,7 @@ func (m *migrator) migrateProjects() error {
 	if len(sourceProjects) == 0 {
 		return nil
 	}
-	targetProjects, err := github.ProjectsToSlice(m.target.ListProjects())
+	targetProjects, err := github.ProjectsToSlice(m.target.ListProjects(ctx))
 	if err != nil {
 		return err
 	}
@@ -30,50 +31,55 @@ func (m *migrator) migrateProjects() error {
 	}
 	for _, p := range sourceProjects {
 		fmt.Printf("[=>] migrating a project: %s\n", p.Name)
-		for p.Number > largestProjectNumber+1 {
-			q, err := m.target.CreateProject(&github.CreateProjectParams{
+		// the numbers are not aligned in the non-empty target (see OffsetNumbers)
+		for !m.offsetNumbers && p.Number > largestProjectNumber+1 {
+			q, err := m.target.CreateProject(ctx, &github.CreateProjectParams{
 				Name: "[Deleted project]",
 			})
 			if err != nil {
 				return err
 			}
 			largestProjectNumber = q.Number
-			if err := m.target.DeleteProject(q.ID); err != nil {
+			if err := m.target.DeleteProject(ctx, q.ID); err != nil {
 				return err
 			}
 		}
 		q := lookupProject(targetProjects, p)
-		body := m.commentFilters.apply(p.Body)
+		body, err := m.filterText(p.Body, p.HTMLURL, "project body")
+		if err != nil {
+			return err
+		}
 		if q == nil {
 			fmt.Printf("[>>] creating a new project: %s\n", p.Name)
-			if q, err = m.target.CreateProject(&github.CreateProjectParams{
+			if q, err = m.target.CreateProject(ctx, &github.CreateProjectParams{
 				Name: p.Name, Body: body,
 			}); err != nil {
 				return err
 			}
 			largestProjectNumber = q.Number
 		}
+		m.recordURL("project", p.HTMLURL, q.HTMLURL)
 		if body != q.Body || p.State != q.State {
 			fmt.Printf("[|>] updating an existing project: %s\n", p.Name)
-			if q, err = m.target.UpdateProject(q.ID, &github.UpdateProjectParams{
+			if q, err = m.target.UpdateProject(ctx, q.ID, &github.UpdateProjectParams{
 				// Do not update name.
 				Body: body, State: p.State,
 			}); err != nil {
 				return err
 			}
 		}
-		if err := m.migrateProjectColumns(p.ID, q.ID); err != nil {
+		if err := m.migrateProjectColumns(ctx, p.ID, q.ID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (m *migrator) getProject(id int) (*github.Project, error) {
+func (m *migrator) getProject(ctx context.Context, id int) (*github.Project, error) {
 	if p, ok := m.projectByIDs[id]; ok {
 		return p, nil
 	}
-	p, err := m.source.GetProject(id)
+	p, err := m.source.GetProject(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,161 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// Version returns a migrator option to set the version of the migrator, which
+// is recorded in the provenance metadata of the imported issues and comments.
+func Version(version string) Option {
+	return func(m *migrator) {
+		m.version = version
+	}
+}
+
+// provenance represents the metadata of the source object, which is embedded
+// in each imported issue and comment as a hidden HTML comment. The content
+// hash is the SHA-256 of the body following the metadata line, so that the
+// tools can detect the modification after importing.
+type provenance struct {
+	Migrator         string `json:"migrator"`
+	Version          string `json:"version,omitempty"`
+	SourceType       string `json:"source_type"`
+	SourceURL        string `json:"source_url"`
+	SourceIDs        []int  `json:"source_ids,omitempty"`
+	SourceNumber     int    `json:"source_number,omitempty"`
+	ReviewCommentIDs []int  `json:"review_comment_ids,omitempty"`
+	Author           string `json:"author,omitempty"`
+	CreatedAt        string `json:"created_at,omitempty"`
+	UpdatedAt        string `json:"updated_at,omitempty"`
+	Part             int    `json:"part,omitempty"`
+	ContentHash      string `json:"content_hash"`
+}
+
+const (
+	provenancePrefix = "<!-- github-migrator:provenance "
+	provenanceSuffix = " -->\n"
+)
+
+func (m *migrator) newProvenance(sourceType, sourceURL string, user *github.User) *provenance {
+	p := &provenance{
+		Migrator:   "github-migrator",
+		Version:    m.version,
+		SourceType: sourceType,
+		SourceURL:  sourceURL,
+	}
+	if user != nil {
+		p.Author = user.Login
+	}
+	return p
+}
+
+func (m *migrator) newIssueProvenance(issue *github.Issue) *provenance {
+	sourceType := "issue"
+	if issue.PullRequest != nil {
+		sourceType = "pull_request"
+	}
+	p := m.newProvenance(sourceType, issue.HTMLURL, issue.User)
+	p.SourceIDs = appendSourceID(nil, issue.ID)
+	p.SourceNumber = issue.Number
+	p.CreatedAt, p.UpdatedAt = issue.CreatedAt, issue.UpdatedAt
+	return p
+}
+
+func appendSourceID(ids []int, id int) []int {
+	if id == 0 {
+		return ids
+	}
+	return append(ids, id)
+}
+
+// embed prepends the metadata of the part (starting from 0) of the body.
+func (p *provenance) embed(body string, part int) string {
+	if p == nil {
+		return body
+	}
+	q := *p
+	q.Part = part
+	q.ContentHash = contentHash(body)
+	bs, err := json.Marshal(&q) // the HTML characters are escaped
+	if err != nil {
+		panic(err)
+	}
+	return provenancePrefix + string(bs) + provenanceSuffix + body
+}
+
+// bodyLength returns the length of each part of the body, subtracting the
+// length of the embedded metadata from n. The lists of the ids (which grow
+// with the reviews and grouped events) are dropped when the metadata takes
+// more than a quarter of the length.
+func (p *provenance) bodyLength(n int) int {
+	if p == nil {
+		return n
+	}
+	if l := p.length(); l <= n/4 {
+		return n - l
+	}
+	p.SourceIDs, p.ReviewCommentIDs = nil, nil
+	return n - p.length()
+}
+
+// length returns the length of the metadata with the longest part number.
+func (p *provenance) length() int {
+	return len(p.embed("", math.MaxInt32))
+}
+
+func contentHash(body string) string {
+	h := sha256.Sum256([]byte(body))
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// parseProvenance parses the provenance metadata of the body, and returns the
+// metadata and the remaining body.
+func parseProvenance(body string) (*provenance, string, bool) {
+	if !strings.HasPrefix(body, provenancePrefix) {
+		return nil, body, false
+	}
+	i := strings.Index(body, provenanceSuffix)
+	if i < 0 {
+		return nil, body, false
+	}
+	var p provenance
+	if err := json.Unmarshal([]byte(body[len(provenancePrefix):i]), &p); err != nil {
+		return nil, body, false
+	}
+	return &p, body[i+len(provenanceSuffix):], true
+}
+
+// importProvenances holds the metadata of the issue and comments to be
+// embedded on splitting the import.
+type importProvenances struct {
+	issue    *provenance
+	comments map[*github.ImportComment]*provenance
+}
+
+func (ps *importProvenances) add(c *github.ImportComment, p *provenance) *github.ImportComment {
+	if ps.comments == nil {
+		ps.comments = make(map[*github.ImportComment]*provenance)
+	}
+	ps.comments[c] = p
+	return c
+}
+
+func (ps *importProvenances) issueProvenance() *provenance {
+	if ps == nil {
+		return nil
+	}
+	return ps.issue
+}
+
+func (ps *importProvenances) commentProvenance(c *github.ImportComment) *provenance {
+	if ps == nil {
+		return nil
+	}
+	return ps.comments[c]
+}
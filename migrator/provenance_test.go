@@ -0,0 +1,109 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestProvenance(t *testing.T) {
+	m := &migrator{version: "1.0.0"}
+	p := m.newIssueProvenance(&github.Issue{
+		ID:        100,
+		Number:    1,
+		HTMLURL:   "http://localhost/example/source/issues/1",
+		User:      &github.User{Login: "sample-user-1"},
+		CreatedAt: "2019-11-10T12:00:00Z",
+		UpdatedAt: "2019-11-11T12:00:00Z",
+	})
+	body := p.embed("Example body --> <!--\n", 0)
+	assert.True(t, strings.HasPrefix(body, "<!-- github-migrator:provenance {"))
+	assert.Equal(t, 1, strings.Count(strings.SplitN(body, "\n", 2)[0], "-->"))
+
+	q, rest, ok := parseProvenance(body)
+	require.True(t, ok)
+	assert.Equal(t, "Example body --> <!--\n", rest)
+	assert.Equal(t, &provenance{
+		Migrator:     "github-migrator",
+		Version:      "1.0.0",
+		SourceType:   "issue",
+		SourceURL:    "http://localhost/example/source/issues/1",
+		SourceIDs:    []int{100},
+		SourceNumber: 1,
+		Author:       "sample-user-1",
+		CreatedAt:    "2019-11-10T12:00:00Z",
+		UpdatedAt:    "2019-11-11T12:00:00Z",
+		ContentHash:  contentHash(rest),
+	}, q)
+
+	_, rest, ok = parseProvenance("Example body")
+	assert.False(t, ok)
+	assert.Equal(t, "Example body", rest)
+}
+
+func TestSplitImportProvenances(t *testing.T) {
+	m := &migrator{}
+	comment := &github.ImportComment{
+		Body:      strings.Repeat("Example comment\n", maxBodyLength/10),
+		CreatedAt: "2019-11-10T13:00:00Z",
+	}
+	ps := &importProvenances{
+		issue: m.newProvenance("issue", "http://localhost/example/source/issues/1", nil),
+	}
+	ps.add(comment, m.newProvenance("comment", "http://localhost/example/source/issues/1#issuecomment-1", nil))
+	imp := splitImport(&github.Import{
+		Issue:    &github.ImportIssue{Body: "Example body"},
+		Comments: []*github.ImportComment{comment},
+	}, ps, maxBodyLength)
+	require.Len(t, imp.Comments, 2)
+	for i, body := range []string{imp.Issue.Body, imp.Comments[0].Body, imp.Comments[1].Body} {
+		assert.LessOrEqual(t, len(body), maxBodyLength)
+		p, rest, ok := parseProvenance(body)
+		require.True(t, ok)
+		assert.Equal(t, contentHash(rest), p.ContentHash)
+		if i == 0 {
+			assert.Equal(t, "issue", p.SourceType)
+		} else {
+			assert.Equal(t, "comment", p.SourceType)
+			assert.Equal(t, i-1, p.Part)
+		}
+	}
+}
+
+func TestSplitImportLongProvenances(t *testing.T) {
+	m := &migrator{}
+	ps := &importProvenances{}
+	var comments []*github.ImportComment
+	for i, n := range []int{500, 5000} {
+		p := m.newProvenance("review",
+			fmt.Sprintf("http://localhost/example/source/pull/1#pullrequestreview-%d", i+1), nil)
+		for j := 0; j < n; j++ {
+			p.ReviewCommentIDs = append(p.ReviewCommentIDs, 1000000000+j)
+		}
+		comments = append(comments, ps.add(&github.ImportComment{
+			Body:      strings.Repeat("Example comment\n", (maxBodyLength-100)/16),
+			CreatedAt: "2019-11-10T13:00:00Z",
+		}, p))
+	}
+	imp := splitImport(&github.Import{
+		Issue:    &github.ImportIssue{Body: "Example body"},
+		Comments: comments,
+	}, ps, maxBodyLength)
+	require.Len(t, imp.Comments, 4)
+	for i, c := range imp.Comments {
+		assert.LessOrEqual(t, len(c.Body), maxBodyLength)
+		p, rest, ok := parseProvenance(c.Body)
+		require.True(t, ok)
+		assert.Equal(t, contentHash(rest), p.ContentHash)
+		if i < 2 {
+			assert.Len(t, p.ReviewCommentIDs, 500)
+		} else {
+			assert.Nil(t, p.ReviewCommentIDs)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package migrator
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReplaceRule represents a rule to replace the contents with a regular expression.
+type ReplaceRule struct {
+	pattern *regexp.Regexp
+	replace string
+}
+
+// NewReplaceRule creates a new ReplaceRule. The replacement can refer to the
+// submatches like $1 (see regexp.Regexp.Expand for the syntax).
+func NewReplaceRule(pattern, replace string) (*ReplaceRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplaceRule{pattern: re, replace: replace}, nil
+}
+
+// ReadReplaceRules reads the rules in YAML format, which is a list of objects
+// with the pattern and replace keys.
+func ReadReplaceRules(r io.Reader) ([]*ReplaceRule, error) {
+	var xs []struct {
+		Pattern string `yaml:"pattern"`
+		Replace string `yaml:"replace"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&xs); err != nil && err != io.EOF {
+		return nil, err
+	}
+	rules := make([]*ReplaceRule, len(xs))
+	for i, x := range xs {
+		rule, err := NewReplaceRule(x.Pattern, x.Replace)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule at index %d: %w", i, err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// ReplaceRules returns a migrator option to apply the replace rules in order.
+// The rules are applied to the titles, bodies, comments, project notes and
+// milestone descriptions.
+func ReplaceRules(rules []*ReplaceRule) Option {
+	return CommentFilter(func(src string) string {
+		for _, rule := range rules {
+			src = rule.pattern.ReplaceAllString(src, rule.replace)
+		}
+		return src
+	})
+}
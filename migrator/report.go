@@ -0,0 +1,28 @@
+package migrator
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Report returns a migrator option to write the migration report in JSON
+// lines. The report records what was changed or lost on the migration.
+func Report(w io.Writer) Option {
+	return func(m *migrator) {
+		m.reportEncoder = json.NewEncoder(w)
+	}
+}
+
+type reportEntry struct {
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (m *migrator) report(e *reportEntry) error {
+	if m.reportEncoder == nil {
+		return nil
+	}
+	return m.reportEncoder.Encode(e)
+}
@@ -1,12 +1,13 @@
 package migrator
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
-func (m *migrator) migrateRepo() error {
+func (m *migrator) migrateRepo(ctx context.Context) error {
 	fmt.Printf(
 		"[=>] migrating: %s (%s) => %s (%s)\n",
 		m.sourceRepo.Name, m.sourceRepo.HTMLURL,
@@ -15,7 +16,7 @@ func (m *migrator) migrateRepo() error {
 
 	if params, ok := buildUpdateRepoParams(m.sourceRepo, m.targetRepo); ok {
 		fmt.Printf("[|>] updating the repository: %s\n", m.targetRepo.HTMLURL)
-		if _, err := m.target.Update(params); err != nil {
+		if _, err := m.target.Update(ctx, params); err != nil {
 			return err
 		}
 	}
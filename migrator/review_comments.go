@@ -0,0 +1,110 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// isOutdatedReviewComment reports whether the review comment is on the lines
+// which are changed afterwards. The position is null for outdated comments.
+func isOutdatedReviewComment(c *github.ReviewComment) bool {
+	return c.Position == nil && c.OriginalCommitID != ""
+}
+
+func isMultiLineReviewComment(c *github.ReviewComment) bool {
+	return c.StartLine > 0 && c.StartLine < c.Line
+}
+
+// reviewCommentSideOf returns the prefixes of the diff lines on the side of
+// the review comment, and the sign of the line numbers.
+func reviewCommentSideOf(c *github.ReviewComment) (string, string) {
+	if c.Side == "LEFT" {
+		return "- ", "-"
+	}
+	return "+ ", "+"
+}
+
+// trimDiffHunk trims the diff hunk to the commented lines. The diff hunk ends
+// with the last commented line, so the hunk is trimmed from the end while
+// counting the lines on the side of the comment. The diff hunk is kept for
+// single line comments, to show the context of the line.
+func trimDiffHunk(c *github.ReviewComment) string {
+	lines := strings.Split(strings.TrimRight(c.DiffHunk, "\n"), "\n")
+	if !isMultiLineReviewComment(c) {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[commentedLinesIndex(c, lines):], "\n")
+}
+
+func commentedLinesIndex(c *github.ReviewComment, lines []string) int {
+	if !isMultiLineReviewComment(c) {
+		return len(lines) - 1
+	}
+	prefixes, _ := reviewCommentSideOf(c)
+	n := c.Line - c.StartLine + 1
+	for i := len(lines) - 1; i > 0; i-- {
+		if l := lines[i]; l != "" && strings.IndexByte(prefixes, l[0]) >= 0 {
+			if n--; n == 0 {
+				return i
+			}
+		}
+	}
+	if strings.HasPrefix(lines[0], "@@") {
+		return 1
+	}
+	return 0
+}
+
+// commentedLines returns the contents of the commented lines.
+func commentedLines(c *github.ReviewComment) []string {
+	lines := strings.Split(strings.TrimRight(c.DiffHunk, "\n"), "\n")
+	prefixes, _ := reviewCommentSideOf(c)
+	var xs []string
+	for _, l := range lines[commentedLinesIndex(c, lines):] {
+		if l != "" && strings.IndexByte(prefixes, l[0]) >= 0 {
+			xs = append(xs, l[1:])
+		}
+	}
+	return xs
+}
+
+// buildReviewCommentHeader builds the header line of the diff, including the
+// range of the lines for multi-line comments.
+func buildReviewCommentHeader(c *github.ReviewComment) string {
+	header := "# " + c.Path
+	if isMultiLineReviewComment(c) {
+		_, sign := reviewCommentSideOf(c)
+		header += fmt.Sprintf(" (lines %s%d to %s%d)", sign, c.StartLine, sign, c.Line)
+	}
+	return header
+}
+
+var suggestionPattern = regexp.MustCompile("(?s)```suggestion[^\\n]*\\n(.*?)```")
+
+// buildSuggestions converts the suggested changes to the diffs from the
+// commented lines, since the suggestions do not work on the imported comments.
+func buildSuggestions(c *github.ReviewComment) string {
+	if !strings.Contains(c.Body, "```suggestion") {
+		return c.Body
+	}
+	before := commentedLines(c)
+	return suggestionPattern.ReplaceAllStringFunc(c.Body, func(s string) string {
+		suggestion := suggestionPattern.FindStringSubmatch(s)[1]
+		suggestion = strings.TrimSuffix(strings.ReplaceAll(suggestion, "\r\n", "\n"), "\n")
+		var sb strings.Builder
+		sb.WriteString("<b>Suggested change</b>\n\n```diff\n")
+		for _, l := range before {
+			sb.WriteString("-" + l + "\n")
+		}
+		if suggestion != "" {
+			for _, l := range strings.Split(suggestion, "\n") {
+				sb.WriteString("+" + l + "\n")
+			}
+		}
+		sb.WriteString("```")
+		return sb.String()
+	})
+}
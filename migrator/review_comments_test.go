@@ -0,0 +1,61 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+const testReviewDiffHunk = `@@ -1,5 +1,6 @@
+ line 1
+-line 2
++line 2 changed
++line 3 added
+ line 4
+ line 5`
+
+func TestTrimDiffHunk(t *testing.T) {
+	c := &github.ReviewComment{DiffHunk: testReviewDiffHunk, Line: 5, Side: "RIGHT"}
+	assert.Equal(t, testReviewDiffHunk, trimDiffHunk(c))
+	assert.Equal(t, []string{"line 5"}, commentedLines(c))
+
+	c.StartLine = 3
+	assert.Equal(t, "+line 3 added\n line 4\n line 5", trimDiffHunk(c))
+	assert.Equal(t, []string{"line 3 added", "line 4", "line 5"}, commentedLines(c))
+	assert.Equal(t, "# sample.txt (lines +3 to +5)", buildReviewCommentHeader(&github.ReviewComment{
+		Path: "sample.txt", StartLine: 3, Line: 5, Side: "RIGHT",
+	}))
+
+	c = &github.ReviewComment{DiffHunk: testReviewDiffHunk, StartLine: 1, Line: 4, Side: "LEFT"}
+	assert.Equal(t, " line 1\n-line 2\n+line 2 changed\n+line 3 added\n line 4\n line 5", trimDiffHunk(c))
+
+	c = &github.ReviewComment{DiffHunk: testReviewDiffHunk, StartLine: 1, Line: 100, Side: "RIGHT"}
+	assert.Equal(t, testReviewDiffHunk[len("@@ -1,5 +1,6 @@\n"):], trimDiffHunk(c))
+}
+
+func TestIsOutdatedReviewComment(t *testing.T) {
+	position := 3
+	assert.False(t, isOutdatedReviewComment(&github.ReviewComment{}))
+	assert.False(t, isOutdatedReviewComment(&github.ReviewComment{Position: &position, OriginalCommitID: "sha1"}))
+	assert.True(t, isOutdatedReviewComment(&github.ReviewComment{OriginalCommitID: "sha1"}))
+}
+
+func TestBuildSuggestions(t *testing.T) {
+	c := &github.ReviewComment{
+		DiffHunk:  testReviewDiffHunk,
+		StartLine: 4,
+		Line:      5,
+		Side:      "RIGHT",
+		Body:      "How about this?\r\n```suggestion\r\nline 4 and 5\r\n```\r\nThanks.",
+	}
+	assert.Equal(t, "How about this?\r\n<b>Suggested change</b>\n\n```diff\n"+
+		"-line 4\n-line 5\n+line 4 and 5\n```\r\nThanks.", buildSuggestions(c))
+
+	c.Body = "```suggestion\n```"
+	assert.Equal(t, "<b>Suggested change</b>\n\n```diff\n-line 4\n-line 5\n```", buildSuggestions(c))
+
+	c.Body = "No suggestion."
+	assert.Equal(t, "No suggestion.", buildSuggestions(c))
+}
@@ -0,0 +1,209 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// SecretPolicy represents how to handle the detected secrets.
+type SecretPolicy int
+
+// SecretPolicy ...
+const (
+	// SecretPolicyRedact replaces the secrets with a placeholder.
+	SecretPolicyRedact SecretPolicy = iota + 1
+	// SecretPolicyBlock stops importing the issue containing the secrets.
+	SecretPolicyBlock
+)
+
+// SecretPattern represents a pattern of secrets.
+type SecretPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// NewSecretPattern creates a new SecretPattern.
+func NewSecretPattern(name, pattern string) (*SecretPattern, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretPattern{name: name, pattern: re}, nil
+}
+
+// ReadSecretPatterns reads the patterns in YAML format, which is a list of
+// objects with the name and pattern keys.
+func ReadSecretPatterns(r io.Reader) ([]*SecretPattern, error) {
+	var xs []struct {
+		Name    string `yaml:"name"`
+		Pattern string `yaml:"pattern"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&xs); err != nil && err != io.EOF {
+		return nil, err
+	}
+	patterns := make([]*SecretPattern, len(xs))
+	for i, x := range xs {
+		pattern, err := NewSecretPattern(x.Name, x.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at index %d: %w", i, err)
+		}
+		patterns[i] = pattern
+	}
+	return patterns, nil
+}
+
+var defaultSecretPatterns = []*SecretPattern{
+	{
+		name:    "GitHub token",
+		pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`),
+	},
+	{
+		name:    "AWS access key ID",
+		pattern: regexp.MustCompile(`\b(?:A3T[A-Z0-9]|AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}\b`),
+	},
+	{
+		name:    "AWS secret access key",
+		pattern: regexp.MustCompile(`(?i)\baws_?secret_?access_?key\b["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`),
+	},
+	{
+		name:    "private key",
+		pattern: regexp.MustCompile(`-----BEGIN[ A-Z]* PRIVATE KEY(?: BLOCK)?-----[\s\S]*?-----END[ A-Z]* PRIVATE KEY(?: BLOCK)?-----`),
+	},
+	{
+		name:    "JWT",
+		pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	},
+}
+
+// ScanSecrets returns a migrator option to detect the secrets (GitHub tokens,
+// AWS keys, private keys, JWTs and the given patterns) before importing. The
+// findings are recorded in the migration report (see Report).
+func ScanSecrets(policy SecretPolicy, patterns []*SecretPattern) Option {
+	return func(m *migrator) {
+		m.secretScanner = &secretScanner{
+			policy:   policy,
+			patterns: append(append([]*SecretPattern{}, defaultSecretPatterns...), patterns...),
+		}
+	}
+}
+
+type secretScanner struct {
+	policy   SecretPolicy
+	patterns []*SecretPattern
+}
+
+type secretFinding struct {
+	name, fingerprint string
+}
+
+func (s *secretScanner) scan(src string) (string, []*secretFinding) {
+	var findings []*secretFinding
+	for _, p := range s.patterns {
+		src = p.pattern.ReplaceAllStringFunc(src, func(secret string) string {
+			hash := sha256.Sum256([]byte(secret))
+			findings = append(findings, &secretFinding{
+				name: p.name, fingerprint: hex.EncodeToString(hash[:])[:12],
+			})
+			return "[REDACTED " + p.name + "]"
+		})
+	}
+	return src, findings
+}
+
+// scrubSecrets scrubs the secrets in the contents to be imported. The source
+// issue is not modified (a scrubbed copy is returned), and the elements of the
+// slices are replaced with the scrubbed copies, so that the source objects
+// shared with the provenance metadata, the collision checks and the reports
+// are kept as is.
+func (m *migrator) scrubSecrets(
+	issue *github.Issue, comments []*github.Comment,
+	commits []*github.Commit, commitDiff *string,
+	reviews []*github.Review, reviewComments []*github.ReviewComment,
+) (*github.Issue, error) {
+	if m.secretScanner == nil {
+		return issue, nil
+	}
+	i := *issue
+	if err := m.scrubSecret(&i.Title, i.HTMLURL, "title"); err != nil {
+		return nil, err
+	}
+	if err := m.scrubSecret(&i.Body, i.HTMLURL, "body"); err != nil {
+		return nil, err
+	}
+	for j, c := range comments {
+		c := *c
+		if err := m.scrubSecret(&c.Body, c.HTMLURL, "comment"); err != nil {
+			return nil, err
+		}
+		comments[j] = &c
+	}
+	for j, c := range commits {
+		c := *c
+		if err := m.scrubSecret(&c.Commit.Message, c.HTMLURL, "commit message"); err != nil {
+			return nil, err
+		}
+		commits[j] = &c
+	}
+	if err := m.scrubSecret(commitDiff, i.HTMLURL, "diff"); err != nil {
+		return nil, err
+	}
+	for j, r := range reviews {
+		r := *r
+		if err := m.scrubSecret(&r.Body, r.HTMLURL, "review"); err != nil {
+			return nil, err
+		}
+		reviews[j] = &r
+	}
+	for j, c := range reviewComments {
+		c := *c
+		if err := m.scrubSecret(&c.Body, c.HTMLURL, "review comment"); err != nil {
+			return nil, err
+		}
+		if err := m.scrubSecret(&c.DiffHunk, c.HTMLURL, "review comment diff"); err != nil {
+			return nil, err
+		}
+		reviewComments[j] = &c
+	}
+	return &i, nil
+}
+
+// filterText scrubs the secrets in the text which is not scanned along with
+// the issue (like the project notes), and applies the comment filters.
+func (m *migrator) filterText(src, url, location string) (string, error) {
+	if err := m.scrubSecret(&src, url, location); err != nil {
+		return "", err
+	}
+	return m.commentFilters.apply(src), nil
+}
+
+func (m *migrator) scrubSecret(src *string, url, location string) error {
+	if m.secretScanner == nil {
+		return nil
+	}
+	dst, findings := m.secretScanner.scan(*src)
+	block := m.secretScanner.policy == SecretPolicyBlock
+	for _, f := range findings {
+		e := &reportEntry{
+			Type: "secret_redacted", URL: url, Location: location,
+			Message: fmt.Sprintf("%s (sha256:%s)", f.name, f.fingerprint),
+		}
+		if block {
+			e.Type = "secret_blocked"
+		}
+		if err := m.report(e); err != nil {
+			return err
+		}
+		if block {
+			return fmt.Errorf("%s detected in %s of %s", f.name, location, url)
+		}
+	}
+	*src = dst
+	return nil
+}
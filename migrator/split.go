@@ -0,0 +1,172 @@
+package migrator
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// The import API rejects the body and comments over this length.
+const maxBodyLength = 65536
+
+// The tables of the files and commits over maxTableRows rows are truncated
+// after truncatedTableRows rows, followed by a full-width row of the rest.
+const (
+	maxTableRows       = 100
+	truncatedTableRows = 90
+)
+
+// splitImport splits the body and comments over the length into continuation
+// comments, which are imported right after the original ones. The provenance
+// metadata is embedded in each part.
+func splitImport(imp *github.Import, ps *importProvenances, n int) *github.Import {
+	issueProvenance := ps.issueProvenance()
+	parts := splitBody(imp.Issue.Body, issueProvenance.bodyLength(n))
+	imp.Issue.Body = issueProvenance.embed(parts[0], 0)
+	comments := make([]*github.ImportComment, 0, len(parts)-1+len(imp.Comments))
+	for i, body := range parts[1:] {
+		comments = append(comments, &github.ImportComment{
+			Body:      issueProvenance.embed(body, i+1),
+			CreatedAt: imp.Issue.CreatedAt,
+		})
+	}
+	for _, c := range imp.Comments {
+		p := ps.commentProvenance(c)
+		for i, body := range splitBody(c.Body, p.bodyLength(n)) {
+			comments = append(comments, &github.ImportComment{Body: p.embed(body, i), CreatedAt: c.CreatedAt})
+		}
+	}
+	imp.Comments = comments
+	return imp
+}
+
+const (
+	continuedMarker = "(continued)\n\n"
+	// reserved length for the closing and reopening tags of each part
+	splitReserveLength = 1024
+)
+
+var (
+	splitOpenTagPattern  = regexp.MustCompile(`^<(details|table|tr|td|th)(?:\s[^<>]*)?>$`)
+	splitCloseTagPattern = regexp.MustCompile(`^</(details|table|tr|td|th)>$`)
+)
+
+// splitBody splits the body at the line breaks so that each part fits in the
+// length (in bytes). The code fences and the HTML tags (details and table,
+// which are written in separate lines by the builder) are closed at the end
+// of each part, and reopened in the next part.
+func splitBody(body string, n int) []string {
+	if len(body) <= n {
+		return []string{body}
+	}
+	limit := n - splitReserveLength
+	var xs []string
+	var state splitState
+	var s strings.Builder
+	for _, line := range splitLines(body, limit) {
+		if s.Len() > 0 && s.Len()+len(line) > limit {
+			s.WriteString(state.closers(s.String()))
+			xs = append(xs, s.String())
+			s.Reset()
+			s.WriteString(continuedMarker)
+			s.WriteString(state.openers())
+		}
+		s.WriteString(line)
+		state.update(line)
+	}
+	return append(xs, s.String())
+}
+
+// splitLines splits the string into lines (with the newlines), and the lines
+// longer than the length are split in the middle.
+func splitLines(s string, n int) []string {
+	var xs []string
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+		if i > n {
+			i = len(truncateString(s, n))
+		}
+		xs = append(xs, s[:i])
+		s = s[i:]
+	}
+	return xs
+}
+
+type splitState struct {
+	fence string
+	tags  []string
+}
+
+func (st *splitState) update(line string) {
+	if !strings.HasSuffix(line, "\n") {
+		return // a part of a long line
+	}
+	trimmed := strings.TrimSpace(line)
+	if st.fence != "" {
+		if trimmed == "```" {
+			st.fence = ""
+		}
+		return
+	}
+	if strings.HasPrefix(trimmed, "```") && strings.Count(trimmed, "```") == 1 {
+		st.fence = line
+	} else if splitOpenTagPattern.MatchString(trimmed) {
+		st.tags = append(st.tags, line)
+	} else if xs := splitCloseTagPattern.FindStringSubmatch(trimmed); xs != nil {
+		for i := len(st.tags) - 1; i >= 0; i-- {
+			if tagName(st.tags[i]) == xs[1] {
+				st.tags = st.tags[:i]
+				break
+			}
+		}
+	}
+}
+
+func (st *splitState) closers(s string) string {
+	var t strings.Builder
+	if !strings.HasSuffix(s, "\n") {
+		t.WriteString("\n")
+	}
+	if st.fence != "" {
+		t.WriteString("```\n")
+	}
+	for i := len(st.tags) - 1; i >= 0; i-- {
+		t.WriteString("</" + tagName(st.tags[i]) + ">\n")
+	}
+	return t.String()
+}
+
+func (st *splitState) openers() string {
+	var t strings.Builder
+	for _, tag := range st.tags {
+		t.WriteString(tag)
+		if tagName(tag) == "details" {
+			t.WriteString("<summary>(continued)</summary>\n")
+		}
+	}
+	if st.fence != "" {
+		t.WriteString("\n" + st.fence)
+	}
+	return t.String()
+}
+
+func tagName(line string) string {
+	return splitOpenTagPattern.FindStringSubmatch(strings.TrimSpace(line))[1]
+}
+
+// truncateString truncates the string to the length (in bytes) without
+// breaking the characters.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,63 @@
+package migrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestSplitBody(t *testing.T) {
+	body := "<table>\n<tr>\n  <td>\n  <details>\n    <summary>diff</summary>\n\n```diff\n" +
+		strings.Repeat("+added\n", 350) + "```\n  </details>\n  </td>\n</tr>\n</table>\n\nExample body\n"
+	xs := splitBody(body, 2500)
+	require.Len(t, xs, 2)
+	assert.LessOrEqual(t, len(xs[0]), 2500)
+	assert.True(t, strings.HasSuffix(xs[0], "+added\n```\n</details>\n</td>\n</tr>\n</table>\n"))
+	assert.True(t, strings.HasPrefix(xs[1], "(continued)\n\n<table>\n<tr>\n  <td>\n  <details>\n"+
+		"<summary>(continued)</summary>\n\n```diff\n+added\n"))
+	assert.True(t, strings.HasSuffix(xs[1], "```\n  </details>\n  </td>\n</tr>\n</table>\n\nExample body\n"))
+	assert.Equal(t, 350, strings.Count(strings.Join(xs, ""), "+added\n"))
+
+	assert.Equal(t, []string{"Example body"}, splitBody("Example body", 3000))
+	xs = splitBody(strings.Repeat("あ", 1000), 2500)
+	require.Len(t, xs, 3)
+	var s strings.Builder
+	for i, x := range xs {
+		if i > 0 {
+			x = strings.TrimPrefix(x, continuedMarker)
+		}
+		if i < len(xs)-1 {
+			x = strings.TrimSuffix(x, "\n")
+		}
+		s.WriteString(x)
+	}
+	assert.Equal(t, strings.Repeat("あ", 1000), s.String())
+}
+
+func TestSplitImport(t *testing.T) {
+	imp := splitImport(&github.Import{
+		Issue: &github.ImportIssue{
+			Body:      strings.Repeat("Example body\n", maxBodyLength/10),
+			CreatedAt: "2019-11-10T12:00:00Z",
+		},
+		Comments: []*github.ImportComment{
+			{Body: "Example comment 1", CreatedAt: "2019-11-10T13:00:00Z"},
+			{Body: strings.Repeat("Example comment 2\n", maxBodyLength/10), CreatedAt: "2019-11-10T14:00:00Z"},
+		},
+	}, nil, maxBodyLength)
+	assert.LessOrEqual(t, len(imp.Issue.Body), maxBodyLength)
+	require.Len(t, imp.Comments, 4)
+	for i, createdAt := range []string{
+		"2019-11-10T12:00:00Z", "2019-11-10T13:00:00Z",
+		"2019-11-10T14:00:00Z", "2019-11-10T14:00:00Z",
+	} {
+		assert.LessOrEqual(t, len(imp.Comments[i].Body), maxBodyLength)
+		assert.Equal(t, createdAt, imp.Comments[i].CreatedAt)
+	}
+	assert.True(t, strings.HasPrefix(imp.Comments[0].Body, continuedMarker+"Example body\n"))
+	assert.Equal(t, "Example comment 1", imp.Comments[1].Body)
+}
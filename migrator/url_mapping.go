@@ -0,0 +1,133 @@
+package migrator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// URLMapping records the URLs of the issues, pull requests, milestones,
+// projects and repositories in the source, and the corresponding URLs in the
+// target. This is useful to set up the redirects from the source.
+type URLMapping struct {
+	entries []*URLMappingEntry
+}
+
+// URLMappingEntry represents an entry of URLMapping.
+type URLMappingEntry struct {
+	Type      string `json:"type"`
+	SourceURL string `json:"source_url"`
+	TargetURL string `json:"target_url"`
+}
+
+// NewURLMapping creates a new URLMapping.
+func NewURLMapping() *URLMapping {
+	return &URLMapping{}
+}
+
+// RecordURLs returns a migrator option to record the URLs to the mapping. The
+// mapping can be shared by the migrators merged into one target.
+func RecordURLs(mapping *URLMapping) Option {
+	return func(m *migrator) {
+		m.urlMapping = mapping
+	}
+}
+
+func (m *migrator) recordURL(typ, sourceURL, targetURL string) {
+	if m.urlMapping == nil || sourceURL == "" || targetURL == "" {
+		return
+	}
+	m.urlMapping.entries = append(m.urlMapping.entries, &URLMappingEntry{
+		Type: typ, SourceURL: sourceURL, TargetURL: targetURL,
+	})
+}
+
+func (m *migrator) recordIssueURL(sourceIssue *github.Issue) {
+	typ := "issue"
+	if sourceIssue.PullRequest != nil {
+		typ = "pull_request"
+	}
+	m.recordURL(typ, sourceIssue.HTMLURL, fmt.Sprintf(
+		"%s/issues/%d", m.targetRepo.HTMLURL, m.targetIssueNumber(sourceIssue.Number),
+	))
+}
+
+// sortedEntries returns the entries with the repositories at last, since the
+// redirects of the repositories are the fallbacks of the other URLs.
+func (u *URLMapping) sortedEntries() []*URLMappingEntry {
+	xs := make([]*URLMappingEntry, 0, len(u.entries))
+	var repos []*URLMappingEntry
+	for _, e := range u.entries {
+		if e.Type == "repository" {
+			repos = append(repos, e)
+		} else {
+			xs = append(xs, e)
+		}
+	}
+	return append(xs, repos...)
+}
+
+// WriteCSV writes the mapping in CSV (with the header line).
+func (u *URLMapping) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"type", "source_url", "target_url"}); err != nil {
+		return err
+	}
+	for _, e := range u.sortedEntries() {
+		if err := cw.Write([]string{e.Type, e.SourceURL, e.TargetURL}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the mapping in JSON (an array of the entries).
+func (u *URLMapping) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(u.sortedEntries())
+}
+
+// WriteNginx writes the rewrite directives of nginx.
+func (u *URLMapping) WriteNginx(w io.Writer) error {
+	return u.writeRewriteRules(w, "", "rewrite %s %s permanent;\n")
+}
+
+// WriteApache writes the rewrite rules of Apache (mod_rewrite in the server
+// configuration, not in .htaccess).
+func (u *URLMapping) WriteApache(w io.Writer) error {
+	return u.writeRewriteRules(w, "RewriteEngine On\n", "RewriteRule %s %s [R=301,L]\n")
+}
+
+// writeRewriteRules writes the rules matching the paths of the source URLs. The
+// sub-paths of the issues (like the files of pull requests) are redirected to
+// the issues, and the other paths of the repositories are kept.
+func (u *URLMapping) writeRewriteRules(w io.Writer, header, format string) error {
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+	for _, e := range u.sortedEntries() {
+		sourceURL, err := url.Parse(e.SourceURL)
+		if err != nil {
+			return err
+		}
+		pattern := "^" + regexp.QuoteMeta(sourceURL.Path)
+		targetURL := e.TargetURL
+		if e.Type == "repository" {
+			pattern += "(/.*)?$"
+			targetURL += "$1"
+		} else {
+			pattern += "(?:/.*)?$"
+		}
+		if _, err := fmt.Fprintf(w, format, pattern, targetURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
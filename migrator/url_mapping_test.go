@@ -0,0 +1,85 @@
+package migrator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func newURLMappingTestMapping() *URLMapping {
+	mapping := NewURLMapping()
+	m := New(nil, nil, nil, RecordURLs(mapping)).(*migrator)
+	m.targetRepo = &github.Repo{FullName: "example/target", HTMLURL: "https://github.com/example/target"}
+	m.targetNumbers = map[int]int{1: 11, 2: 12}
+	m.recordURL("repository", "http://localhost/example/source", "https://github.com/example/target")
+	m.recordIssueURL(&github.Issue{Number: 1, HTMLURL: "http://localhost/example/source/issues/1"})
+	m.recordIssueURL(&github.Issue{
+		Number: 2, HTMLURL: "http://localhost/example/source/pull/2",
+		PullRequest: &github.IssuePullRequest{},
+	})
+	m.recordURL("milestone", "http://localhost/example/source/milestone/1", "https://github.com/example/target/milestone/3")
+	m.recordURL("project", "http://localhost/example/source/projects/1", "")
+	return mapping
+}
+
+func TestURLMappingWriteCSV(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, newURLMappingTestMapping().WriteCSV(buf))
+	assert.Equal(t, `type,source_url,target_url
+issue,http://localhost/example/source/issues/1,https://github.com/example/target/issues/11
+pull_request,http://localhost/example/source/pull/2,https://github.com/example/target/issues/12
+milestone,http://localhost/example/source/milestone/1,https://github.com/example/target/milestone/3
+repository,http://localhost/example/source,https://github.com/example/target
+`, buf.String())
+}
+
+func TestURLMappingWriteJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, newURLMappingTestMapping().WriteJSON(buf))
+	assert.Equal(t, `[
+  {
+    "type": "issue",
+    "source_url": "http://localhost/example/source/issues/1",
+    "target_url": "https://github.com/example/target/issues/11"
+  },
+  {
+    "type": "pull_request",
+    "source_url": "http://localhost/example/source/pull/2",
+    "target_url": "https://github.com/example/target/issues/12"
+  },
+  {
+    "type": "milestone",
+    "source_url": "http://localhost/example/source/milestone/1",
+    "target_url": "https://github.com/example/target/milestone/3"
+  },
+  {
+    "type": "repository",
+    "source_url": "http://localhost/example/source",
+    "target_url": "https://github.com/example/target"
+  }
+]
+`, buf.String())
+}
+
+func TestURLMappingWriteRewriteRules(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, newURLMappingTestMapping().WriteNginx(buf))
+	assert.Equal(t, `rewrite ^/example/source/issues/1(?:/.*)?$ https://github.com/example/target/issues/11 permanent;
+rewrite ^/example/source/pull/2(?:/.*)?$ https://github.com/example/target/issues/12 permanent;
+rewrite ^/example/source/milestone/1(?:/.*)?$ https://github.com/example/target/milestone/3 permanent;
+rewrite ^/example/source(/.*)?$ https://github.com/example/target$1 permanent;
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, newURLMappingTestMapping().WriteApache(buf))
+	assert.Equal(t, `RewriteEngine On
+RewriteRule ^/example/source/issues/1(?:/.*)?$ https://github.com/example/target/issues/11 [R=301,L]
+RewriteRule ^/example/source/pull/2(?:/.*)?$ https://github.com/example/target/issues/12 [R=301,L]
+RewriteRule ^/example/source/milestone/1(?:/.*)?$ https://github.com/example/target/milestone/3 [R=301,L]
+RewriteRule ^/example/source(/.*)?$ https://github.com/example/target$1 [R=301,L]
+`, buf.String())
+}
@@ -1,6 +1,7 @@
 package migrator
 
 import (
+	"context"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
@@ -18,7 +19,16 @@ func (m *migrator) isTargetMember(name string) (bool, error) {
 	return false, nil
 }
 
-func (m *migrator) lookupUser(name string) (*github.User, error) {
+// targetLogin maps the login of the source user. Only the user mapping is
+// applied, since the other filters are for the texts.
+func (m *migrator) targetLogin(login string) string {
+	if m.userFilter == nil {
+		return login
+	}
+	return m.userFilter(login)
+}
+
+func (m *migrator) lookupUser(ctx context.Context, name string) (*github.User, error) {
 	if u, ok := m.userByNames[name]; ok {
 		return u, nil
 	}
@@ -30,7 +40,7 @@ func (m *migrator) lookupUser(name string) (*github.User, error) {
 			return member.ToUser(), nil
 		}
 	}
-	u, err := m.target.GetUser(name)
+	u, err := m.target.GetUser(ctx, name)
 	if err != nil {
 		if m.errorUserByNames == nil {
 			m.errorUserByNames = make(map[string]error)
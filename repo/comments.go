@@ -1,8 +1,17 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListComments lists the comments.
-func (r *Repo) ListComments(issueNumber int) github.Comments {
-	return r.cli.ListComments(r.path, issueNumber)
+func (r *Repo) ListComments(ctx context.Context, issueNumber int) github.Comments {
+	return r.cli.ListComments(ctx, r.path, issueNumber)
+}
+
+// CreateComment creates a comment.
+func (r *Repo) CreateComment(ctx context.Context, issueNumber int, body string) (*github.Comment, error) {
+	return r.cli.CreateComment(ctx, r.path, issueNumber, body)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,27 @@ func TestRepoListComments(t *testing.T) {
 			return github.CommentsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.CommentsToSlice(repo.ListComments(1))
+	got, err := github.CommentsToSlice(repo.ListComments(context.Background(), 1))
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCreateComment(t *testing.T) {
+	expected := &github.Comment{
+		Body:    "Example body 1",
+		HTMLURL: "http://localhost/example/test/issues/1#issuecomment-1",
+	}
+	repo := New(github.NewMockClient(
+		github.MockCreateComment(func(path string, issueNumber int, body string) (*github.Comment, error) {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			return &github.Comment{
+				Body:    body,
+				HTMLURL: "http://localhost/example/test/issues/1#issuecomment-1",
+			}, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateComment(context.Background(), 1, "Example body 1")
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
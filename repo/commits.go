@@ -1,8 +1,12 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListPullReqCommits lists the commits of a pull request.
-func (r *Repo) ListPullReqCommits(pullNumber int) github.Commits {
-	return r.cli.ListPullReqCommits(r.path, pullNumber)
+func (r *Repo) ListPullReqCommits(ctx context.Context, pullNumber int) github.Commits {
+	return r.cli.ListPullReqCommits(ctx, r.path, pullNumber)
 }
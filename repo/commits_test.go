@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListPullReqCommits(t *testing.T) {
 			return github.CommitsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.CommitsToSlice(repo.ListPullReqCommits(10))
+	got, err := github.CommitsToSlice(repo.ListPullReqCommits(context.Background(), 10))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
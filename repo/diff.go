@@ -1,11 +1,18 @@
 package repo
 
+import "context"
+
 // GetDiff gets the diff.
-func (r *Repo) GetDiff(sha string) (string, error) {
-	return r.cli.GetDiff(r.path, sha)
+func (r *Repo) GetDiff(ctx context.Context, sha string) (string, error) {
+	return r.cli.GetDiff(ctx, r.path, sha)
 }
 
 // GetCompare gets the compare.
-func (r *Repo) GetCompare(base, head string) (string, error) {
-	return r.cli.GetCompare(r.path, base, head)
+func (r *Repo) GetCompare(ctx context.Context, base, head string) (string, error) {
+	return r.cli.GetCompare(ctx, r.path, base, head)
+}
+
+// GetComparePatch gets the compare in patch format.
+func (r *Repo) GetComparePatch(ctx context.Context, base, head string) (string, error) {
+	return r.cli.GetComparePatch(ctx, r.path, base, head)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ index 1234567..89abcde 100644
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetDiff("xxxyyy")
+	got, err := repo.GetDiff(context.Background(), "xxxyyy")
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -43,7 +44,25 @@ index 1234567..89abcde 100644
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetCompare("xxxyyy", "zzzwww")
+	got, err := repo.GetCompare(context.Background(), "xxxyyy", "zzzwww")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoGetComparePatch(t *testing.T) {
+	expected := `From 89abcde Mon Sep 17 00:00:00 2001
+From: sample-user <user@example.com>
+Subject: [PATCH] Update README.md
+
+---
+ README.md | 2 +-
+`
+	repo := New(github.NewMockClient(
+		github.MockGetComparePatch(func(string, string, string) (string, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.GetComparePatch(context.Background(), "xxxyyy", "zzzwww")
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -1,8 +1,17 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListEvents lists the events.
-func (r *Repo) ListEvents(issueNumber int) github.Events {
-	return r.cli.ListEvents(r.path, issueNumber)
+func (r *Repo) ListEvents(ctx context.Context, issueNumber int) github.Events {
+	return r.cli.ListEvents(ctx, r.path, issueNumber)
+}
+
+// ListTimeline lists the timeline events.
+func (r *Repo) ListTimeline(ctx context.Context, issueNumber int) github.Events {
+	return r.cli.ListTimeline(ctx, r.path, issueNumber)
 }
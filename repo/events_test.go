@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,35 @@ func TestRepoListEvents(t *testing.T) {
 			return github.EventsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.EventsToSlice(repo.ListEvents(1))
+	got, err := github.EventsToSlice(repo.ListEvents(context.Background(), 1))
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoListTimeline(t *testing.T) {
+	expected := []*github.Event{
+		{
+			Actor: &github.User{Login: "user-1"},
+			Event: "cross-referenced",
+			Source: &github.EventSource{
+				Type:  "issue",
+				Issue: &github.Issue{Number: 2, HTMLURL: "http://localhost/example/test/issues/2"},
+			},
+		},
+		{
+			Actor:    &github.User{Login: "user-2"},
+			Event:    "referenced",
+			CommitID: "0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+	repo := New(github.NewMockClient(
+		github.MockListTimeline(func(path string, issueNumber int) github.Events {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			return github.EventsFromSlice(expected)
+		}),
+	), "example/test")
+	got, err := github.EventsToSlice(repo.ListTimeline(context.Background(), 1))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
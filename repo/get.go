@@ -1,8 +1,12 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // Get the repository.
-func (r *Repo) Get() (*github.Repo, error) {
-	return r.cli.GetRepo(r.path)
+func (r *Repo) Get(ctx context.Context) (*github.Repo, error) {
+	return r.cli.GetRepo(ctx, r.path)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestRepoGet(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.Get()
+	got, err := repo.Get(context.Background())
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// GetGitRef gets the git reference.
+func (r *Repo) GetGitRef(ctx context.Context, ref string) (*github.GitRef, error) {
+	return r.cli.GetGitRef(ctx, r.path, ref)
+}
+
+// CreateGitRef creates a git reference.
+func (r *Repo) CreateGitRef(ctx context.Context, params *github.CreateGitRefParams) (*github.GitRef, error) {
+	return r.cli.CreateGitRef(ctx, r.path, params)
+}
+
+// UpdateGitRef updates the git reference.
+func (r *Repo) UpdateGitRef(ctx context.Context, ref string, params *github.UpdateGitRefParams) (*github.GitRef, error) {
+	return r.cli.UpdateGitRef(ctx, r.path, ref, params)
+}
+
+// CreateGitBlob creates a git blob.
+func (r *Repo) CreateGitBlob(ctx context.Context, params *github.CreateGitBlobParams) (*github.GitBlob, error) {
+	return r.cli.CreateGitBlob(ctx, r.path, params)
+}
+
+// CreateGitTree creates a git tree.
+func (r *Repo) CreateGitTree(ctx context.Context, params *github.CreateGitTreeParams) (*github.GitTree, error) {
+	return r.cli.CreateGitTree(ctx, r.path, params)
+}
+
+// GetGitCommit gets the git commit.
+func (r *Repo) GetGitCommit(ctx context.Context, sha string) (*github.GitCommit, error) {
+	return r.cli.GetGitCommit(ctx, r.path, sha)
+}
+
+// CreateGitCommit creates a git commit.
+func (r *Repo) CreateGitCommit(ctx context.Context, params *github.CreateGitCommitParams) (*github.GitCommit, error) {
+	return r.cli.CreateGitCommit(ctx, r.path, params)
+}
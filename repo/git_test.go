@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestRepoGetGitRef(t *testing.T) {
+	expected := &github.GitRef{Ref: "refs/heads/archive"}
+	repo := New(github.NewMockClient(
+		github.MockGetGitRef(func(string, string) (*github.GitRef, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.GetGitRef(context.Background(), "heads/archive")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCreateGitRef(t *testing.T) {
+	expected := &github.GitRef{Ref: "refs/heads/archive"}
+	repo := New(github.NewMockClient(
+		github.MockCreateGitRef(func(_ string, params *github.CreateGitRefParams) (*github.GitRef, error) {
+			assert.Equal(t, "refs/heads/archive", params.Ref)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateGitRef(context.Background(), &github.CreateGitRefParams{Ref: "refs/heads/archive", SHA: "sha1"})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoUpdateGitRef(t *testing.T) {
+	expected := &github.GitRef{Ref: "refs/heads/archive"}
+	repo := New(github.NewMockClient(
+		github.MockUpdateGitRef(func(_ string, ref string, params *github.UpdateGitRefParams) (*github.GitRef, error) {
+			assert.Equal(t, "heads/archive", ref)
+			assert.Equal(t, "sha2", params.SHA)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.UpdateGitRef(context.Background(), "heads/archive", &github.UpdateGitRefParams{SHA: "sha2"})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCreateGitBlob(t *testing.T) {
+	expected := &github.GitBlob{SHA: "sha1"}
+	repo := New(github.NewMockClient(
+		github.MockCreateGitBlob(func(_ string, params *github.CreateGitBlobParams) (*github.GitBlob, error) {
+			assert.Equal(t, "IyBSRUFETUU=", params.Content)
+			assert.Equal(t, "base64", params.Encoding)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateGitBlob(context.Background(), &github.CreateGitBlobParams{
+		Content: "IyBSRUFETUU=", Encoding: "base64",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCreateGitTree(t *testing.T) {
+	expected := &github.GitTree{SHA: "sha1"}
+	repo := New(github.NewMockClient(
+		github.MockCreateGitTree(func(_ string, params *github.CreateGitTreeParams) (*github.GitTree, error) {
+			assert.Equal(t, "README.md", params.Tree[0].Path)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateGitTree(context.Background(), &github.CreateGitTreeParams{
+		Tree: []*github.GitTreeEntry{{Path: "README.md", Mode: "100644", Type: "blob", Content: "# README"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoGetGitCommit(t *testing.T) {
+	expected := &github.GitCommit{SHA: "sha2", Tree: github.GitTree{SHA: "sha1"}}
+	repo := New(github.NewMockClient(
+		github.MockGetGitCommit(func(string, string) (*github.GitCommit, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.GetGitCommit(context.Background(), "sha2")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCreateGitCommit(t *testing.T) {
+	expected := &github.GitCommit{SHA: "sha2"}
+	repo := New(github.NewMockClient(
+		github.MockCreateGitCommit(func(_ string, params *github.CreateGitCommitParams) (*github.GitCommit, error) {
+			assert.Equal(t, "sha1", params.Tree)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateGitCommit(context.Background(), &github.CreateGitCommitParams{Message: "Add README.md", Tree: "sha1"})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
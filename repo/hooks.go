@@ -1,23 +1,27 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListHooks lists the hooks.
-func (r *Repo) ListHooks() github.Hooks {
-	return r.cli.ListHooks(r.path)
+func (r *Repo) ListHooks(ctx context.Context) github.Hooks {
+	return r.cli.ListHooks(ctx, r.path)
 }
 
 // GetHook gets the hook.
-func (r *Repo) GetHook(hookID int) (*github.Hook, error) {
-	return r.cli.GetHook(r.path, hookID)
+func (r *Repo) GetHook(ctx context.Context, hookID int) (*github.Hook, error) {
+	return r.cli.GetHook(ctx, r.path, hookID)
 }
 
 // CreateHook creates a hook.
-func (r *Repo) CreateHook(params *github.CreateHookParams) (*github.Hook, error) {
-	return r.cli.CreateHook(r.path, params)
+func (r *Repo) CreateHook(ctx context.Context, params *github.CreateHookParams) (*github.Hook, error) {
+	return r.cli.CreateHook(ctx, r.path, params)
 }
 
 // UpdateHook updates the hook.
-func (r *Repo) UpdateHook(hookID int, params *github.UpdateHookParams) (*github.Hook, error) {
-	return r.cli.UpdateHook(r.path, hookID, params)
+func (r *Repo) UpdateHook(ctx context.Context, hookID int, params *github.UpdateHookParams) (*github.Hook, error) {
+	return r.cli.UpdateHook(ctx, r.path, hookID, params)
 }
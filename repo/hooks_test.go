@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListHooks(t *testing.T) {
 			return github.HooksFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.HooksToSlice(repo.ListHooks())
+	got, err := github.HooksToSlice(repo.ListHooks(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -39,7 +40,7 @@ func TestRepoGetHook(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetHook(1)
+	got, err := repo.GetHook(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -55,7 +56,7 @@ func TestRepoCreateHook(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateHook(&github.CreateHookParams{
+	got, err := repo.CreateHook(context.Background(), &github.CreateHookParams{
 		Active: true,
 	})
 	assert.Nil(t, err)
@@ -73,7 +74,7 @@ func TestRepoUpdateHook(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateHook(1, &github.UpdateHookParams{
+	got, err := repo.UpdateHook(context.Background(), 1, &github.UpdateHookParams{
 		Active: true,
 	})
 	assert.Nil(t, err)
@@ -1,13 +1,22 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // Import an object.
-func (r *Repo) Import(x *github.Import) (*github.ImportResult, error) {
-	return r.cli.Import(r.path, x)
+func (r *Repo) Import(ctx context.Context, x *github.Import) (*github.ImportResult, error) {
+	return r.cli.Import(ctx, r.path, x)
 }
 
 // GetImport gets the importing status.
-func (r *Repo) GetImport(id int) (*github.ImportResult, error) {
-	return r.cli.GetImport(r.path, id)
+func (r *Repo) GetImport(ctx context.Context, id int) (*github.ImportResult, error) {
+	return r.cli.GetImport(ctx, r.path, id)
+}
+
+// ListImports lists the importing statuses created since the time.
+func (r *Repo) ListImports(ctx context.Context, since string) ([]*github.ImportResult, error) {
+	return r.cli.ListImports(ctx, r.path, since)
 }
@@ -1,10 +1,14 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListIssues lists the issues.
-func (r *Repo) ListIssues() github.Issues {
-	return r.cli.ListIssues(r.path, &github.ListIssuesParams{
+func (r *Repo) ListIssues(ctx context.Context) github.Issues {
+	return r.cli.ListIssues(ctx, r.path, &github.ListIssuesParams{
 		Filter:    github.ListIssuesParamFilterAll,
 		State:     github.ListIssuesParamStateAll,
 		Direction: github.ListIssuesParamDirectionAsc,
@@ -12,11 +16,36 @@ func (r *Repo) ListIssues() github.Issues {
 }
 
 // GetIssue gets the issue.
-func (r *Repo) GetIssue(issueNumber int) (*github.Issue, error) {
-	return r.cli.GetIssue(r.path, issueNumber)
+func (r *Repo) GetIssue(ctx context.Context, issueNumber int) (*github.Issue, error) {
+	return r.cli.GetIssue(ctx, r.path, issueNumber)
 }
 
 // AddAssignees assigns users to the issue.
-func (r *Repo) AddAssignees(issueNumber int, assignees []string) error {
-	return r.cli.AddAssignees(r.path, issueNumber, assignees)
+func (r *Repo) AddAssignees(ctx context.Context, issueNumber int, assignees []string) (*github.Issue, error) {
+	return r.cli.AddAssignees(ctx, r.path, issueNumber, assignees)
+}
+
+// UpdateIssue updates the issue.
+func (r *Repo) UpdateIssue(ctx context.Context, issueNumber int, params *github.UpdateIssueParams) (*github.Issue, error) {
+	return r.cli.UpdateIssue(ctx, r.path, issueNumber, params)
+}
+
+// CloseIssue closes the issue with the reason.
+func (r *Repo) CloseIssue(ctx context.Context, issueNumber int, stateReason string) (*github.Issue, error) {
+	return r.cli.CloseIssue(ctx, r.path, issueNumber, stateReason)
+}
+
+// LockIssue locks the conversation of the issue.
+func (r *Repo) LockIssue(ctx context.Context, issueNumber int, lockReason string) error {
+	return r.cli.LockIssue(ctx, r.path, issueNumber, lockReason)
+}
+
+// ListPinnedIssues lists the numbers of the pinned issues.
+func (r *Repo) ListPinnedIssues(ctx context.Context) ([]int, error) {
+	return r.cli.ListPinnedIssues(ctx, r.path)
+}
+
+// PinIssue pins the issue.
+func (r *Repo) PinIssue(ctx context.Context, issueNumber int) error {
+	return r.cli.PinIssue(ctx, r.path, issueNumber)
 }
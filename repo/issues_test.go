@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestRepoListIssues(t *testing.T) {
 			return github.IssuesFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.IssuesToSlice(repo.ListIssues())
+	got, err := github.IssuesToSlice(repo.ListIssues(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -51,7 +52,113 @@ func TestRepoGetIssue(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetIssue(1)
+	got, err := repo.GetIssue(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
+
+func TestRepoUpdateIssue(t *testing.T) {
+	expected := &github.Issue{
+		Number:  1,
+		Title:   "Example title 1",
+		State:   github.IssueStateClosed,
+		Body:    "Example body 1",
+		HTMLURL: "http://localhost/example/test/issue/1",
+	}
+	repo := New(github.NewMockClient(
+		github.MockUpdateIssue(func(path string, issueNumber int, params *github.UpdateIssueParams) (*github.Issue, error) {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			return &github.Issue{
+				Number:  issueNumber,
+				Title:   params.Title,
+				State:   params.State,
+				Body:    params.Body,
+				HTMLURL: "http://localhost/example/test/issue/1",
+			}, nil
+		}),
+	), "example/test")
+	got, err := repo.UpdateIssue(context.Background(), 1, &github.UpdateIssueParams{
+		Title: "Example title 1",
+		Body:  "Example body 1",
+		State: github.IssueStateClosed,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoAddAssignees(t *testing.T) {
+	expected := &github.Issue{
+		Number:    1,
+		Title:     "Example title 1",
+		HTMLURL:   "http://localhost/example/test/issue/1",
+		Assignees: []*github.User{{Login: "test-user-1"}, {Login: "test-user-2"}},
+	}
+	repo := New(github.NewMockClient(
+		github.MockAddAssignees(func(path string, issueNumber int, assignees []string) (*github.Issue, error) {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			assert.Equal(t, []string{"test-user-1", "test-user-2"}, assignees)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.AddAssignees(context.Background(), 1, []string{"test-user-1", "test-user-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoCloseIssue(t *testing.T) {
+	expected := &github.Issue{
+		Number:      1,
+		Title:       "Example title 1",
+		State:       github.IssueStateClosed,
+		StateReason: "not_planned",
+		HTMLURL:     "http://localhost/example/test/issue/1",
+	}
+	repo := New(github.NewMockClient(
+		github.MockCloseIssue(func(path string, issueNumber int, stateReason string) (*github.Issue, error) {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			assert.Equal(t, "not_planned", stateReason)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CloseIssue(context.Background(), 1, "not_planned")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoLockIssue(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockLockIssue(func(path string, issueNumber int, lockReason string) error {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			assert.Equal(t, "resolved", lockReason)
+			return nil
+		}),
+	), "example/test")
+	assert.Nil(t, repo.LockIssue(context.Background(), 1, "resolved"))
+}
+
+func TestRepoListPinnedIssues(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockListPinnedIssues(func(path string) ([]int, error) {
+			assert.Equal(t, "example/test", path)
+			return []int{3, 1}, nil
+		}),
+	), "example/test")
+	got, err := repo.ListPinnedIssues(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 1}, got)
+}
+
+func TestRepoPinIssue(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockPinIssue(func(path string, issueNumber int) error {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, 1, issueNumber)
+			return nil
+		}),
+	), "example/test")
+	assert.Nil(t, repo.PinIssue(context.Background(), 1))
+}
@@ -1,18 +1,22 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListLabels lists the labels.
-func (r *Repo) ListLabels() github.Labels {
-	return r.cli.ListLabels(r.path)
+func (r *Repo) ListLabels(ctx context.Context) github.Labels {
+	return r.cli.ListLabels(ctx, r.path)
 }
 
 // CreateLabel creates a new label.
-func (r *Repo) CreateLabel(params *github.CreateLabelParams) (*github.Label, error) {
-	return r.cli.CreateLabel(r.path, params)
+func (r *Repo) CreateLabel(ctx context.Context, params *github.CreateLabelParams) (*github.Label, error) {
+	return r.cli.CreateLabel(ctx, r.path, params)
 }
 
 // UpdateLabel creates a new label.
-func (r *Repo) UpdateLabel(name string, params *github.UpdateLabelParams) (*github.Label, error) {
-	return r.cli.UpdateLabel(r.path, name, params)
+func (r *Repo) UpdateLabel(ctx context.Context, name string, params *github.UpdateLabelParams) (*github.Label, error) {
+	return r.cli.UpdateLabel(ctx, r.path, name, params)
 }
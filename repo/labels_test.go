@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestRepoListLabels(t *testing.T) {
 			return github.LabelsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.LabelsToSlice(repo.ListLabels())
+	got, err := github.LabelsToSlice(repo.ListLabels(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -48,7 +49,7 @@ func TestRepoCreateLabel(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateLabel(&github.CreateLabelParams{
+	got, err := repo.CreateLabel(context.Background(), &github.CreateLabelParams{
 		Name:        "bug",
 		Description: "This is a bug.",
 		Color:       "fc2929",
@@ -70,7 +71,7 @@ func TestRepoUpdateLabel(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateLabel("bug", &github.UpdateLabelParams{
+	got, err := repo.UpdateLabel(context.Background(), "bug", &github.UpdateLabelParams{
 		Name:        "warn",
 		Description: "This is a warning.",
 		Color:       "fcfc29",
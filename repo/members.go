@@ -1,12 +1,13 @@
 package repo
 
 import (
+	"context"
 	"strings"
 
 	"github.com/itchyny/github-migrator/github"
 )
 
 // ListMembers lists the members.
-func (r *Repo) ListMembers() github.Members {
-	return r.cli.ListMembers(strings.Split(r.path, "/")[0])
+func (r *Repo) ListMembers(ctx context.Context) github.Members {
+	return r.cli.ListMembers(ctx, strings.Split(r.path, "/")[0])
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,7 +23,7 @@ func TestListMembers(t *testing.T) {
 			return github.MembersFromSlice(expected)
 		}),
 	), "example")
-	got, err := github.MembersToSlice(repo.ListMembers())
+	got, err := github.MembersToSlice(repo.ListMembers(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -1,28 +1,32 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListMilestones lists the milestones.
-func (r *Repo) ListMilestones(params *github.ListMilestonesParams) github.Milestones {
-	return r.cli.ListMilestones(r.path, params)
+func (r *Repo) ListMilestones(ctx context.Context, params *github.ListMilestonesParams) github.Milestones {
+	return r.cli.ListMilestones(ctx, r.path, params)
 }
 
 // GetMilestone gets the milestone.
-func (r *Repo) GetMilestone(milestoneNumber int) (*github.Milestone, error) {
-	return r.cli.GetMilestone(r.path, milestoneNumber)
+func (r *Repo) GetMilestone(ctx context.Context, milestoneNumber int) (*github.Milestone, error) {
+	return r.cli.GetMilestone(ctx, r.path, milestoneNumber)
 }
 
 // CreateMilestone creates a milestone.
-func (r *Repo) CreateMilestone(params *github.CreateMilestoneParams) (*github.Milestone, error) {
-	return r.cli.CreateMilestone(r.path, params)
+func (r *Repo) CreateMilestone(ctx context.Context, params *github.CreateMilestoneParams) (*github.Milestone, error) {
+	return r.cli.CreateMilestone(ctx, r.path, params)
 }
 
 // UpdateMilestone updates the milestone.
-func (r *Repo) UpdateMilestone(milestoneNumber int, params *github.UpdateMilestoneParams) (*github.Milestone, error) {
-	return r.cli.UpdateMilestone(r.path, milestoneNumber, params)
+func (r *Repo) UpdateMilestone(ctx context.Context, milestoneNumber int, params *github.UpdateMilestoneParams) (*github.Milestone, error) {
+	return r.cli.UpdateMilestone(ctx, r.path, milestoneNumber, params)
 }
 
 // DeleteMilestone deletes the milestone.
-func (r *Repo) DeleteMilestone(milestoneNumber int) error {
-	return r.cli.DeleteMilestone(r.path, milestoneNumber)
+func (r *Repo) DeleteMilestone(ctx context.Context, milestoneNumber int) error {
+	return r.cli.DeleteMilestone(ctx, r.path, milestoneNumber)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListMilestones(t *testing.T) {
 			return github.MilestonesFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.MilestonesToSlice(repo.ListMilestones(nil))
+	got, err := github.MilestonesToSlice(repo.ListMilestones(context.Background(), nil))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -39,7 +40,7 @@ func TestRepoGetMilestone(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetMilestone(1)
+	got, err := repo.GetMilestone(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -54,7 +55,7 @@ func TestRepoCreateMilestone(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateMilestone(&github.CreateMilestoneParams{})
+	got, err := repo.CreateMilestone(context.Background(), &github.CreateMilestoneParams{})
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -69,7 +70,7 @@ func TestRepoUpdateMilestone(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateMilestone(1, &github.UpdateMilestoneParams{})
+	got, err := repo.UpdateMilestone(context.Background(), 1, &github.UpdateMilestoneParams{})
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -80,6 +81,6 @@ func TestRepoDeleteMilestone(t *testing.T) {
 			return nil
 		}),
 	), "example/test")
-	err := repo.DeleteMilestone(1)
+	err := repo.DeleteMilestone(context.Background(), 1)
 	assert.Nil(t, err)
 }
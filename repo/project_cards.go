@@ -1,28 +1,32 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListProjectCards lists the project cards.
-func (r *Repo) ListProjectCards(columnID int) github.ProjectCards {
-	return r.cli.ListProjectCards(columnID)
+func (r *Repo) ListProjectCards(ctx context.Context, columnID int) github.ProjectCards {
+	return r.cli.ListProjectCards(ctx, columnID)
 }
 
 // GetProjectCard gets the project card.
-func (r *Repo) GetProjectCard(projectCardID int) (*github.ProjectCard, error) {
-	return r.cli.GetProjectCard(projectCardID)
+func (r *Repo) GetProjectCard(ctx context.Context, projectCardID int) (*github.ProjectCard, error) {
+	return r.cli.GetProjectCard(ctx, projectCardID)
 }
 
 // CreateProjectCard creates a project card.
-func (r *Repo) CreateProjectCard(columnID int, params *github.CreateProjectCardParams) (*github.ProjectCard, error) {
-	return r.cli.CreateProjectCard(columnID, params)
+func (r *Repo) CreateProjectCard(ctx context.Context, columnID int, params *github.CreateProjectCardParams) (*github.ProjectCard, error) {
+	return r.cli.CreateProjectCard(ctx, columnID, params)
 }
 
 // UpdateProjectCard updates the project card..
-func (r *Repo) UpdateProjectCard(projectCardID int, params *github.UpdateProjectCardParams) (*github.ProjectCard, error) {
-	return r.cli.UpdateProjectCard(projectCardID, params)
+func (r *Repo) UpdateProjectCard(ctx context.Context, projectCardID int, params *github.UpdateProjectCardParams) (*github.ProjectCard, error) {
+	return r.cli.UpdateProjectCard(ctx, projectCardID, params)
 }
 
 // MoveProjectCard moves the project card..
-func (r *Repo) MoveProjectCard(projectCardID int, params *github.MoveProjectCardParams) (*github.ProjectCard, error) {
-	return r.cli.MoveProjectCard(projectCardID, params)
+func (r *Repo) MoveProjectCard(ctx context.Context, projectCardID int, params *github.MoveProjectCardParams) (*github.ProjectCard, error) {
+	return r.cli.MoveProjectCard(ctx, projectCardID, params)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListProjectCards(t *testing.T) {
 			return github.ProjectCardsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.ProjectCardsToSlice(repo.ListProjectCards(1))
+	got, err := github.ProjectCardsToSlice(repo.ListProjectCards(context.Background(), 1))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -39,7 +40,7 @@ func TestRepoGetProjectCard(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetProjectCard(1)
+	got, err := repo.GetProjectCard(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -54,7 +55,7 @@ func TestRepoCreateProjectCard(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateProjectCard(10, nil)
+	got, err := repo.CreateProjectCard(context.Background(), 10, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -69,7 +70,7 @@ func TestRepoUpdateProjectCard(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateProjectCard(1, nil)
+	got, err := repo.UpdateProjectCard(context.Background(), 1, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -84,7 +85,7 @@ func TestRepoMoveProjectCard(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.MoveProjectCard(1, nil)
+	got, err := repo.MoveProjectCard(context.Background(), 1, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -1,23 +1,27 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListProjectColumns lists the project columns.
-func (r *Repo) ListProjectColumns(projectID int) github.ProjectColumns {
-	return r.cli.ListProjectColumns(projectID)
+func (r *Repo) ListProjectColumns(ctx context.Context, projectID int) github.ProjectColumns {
+	return r.cli.ListProjectColumns(ctx, projectID)
 }
 
 // GetProjectColumn gets the project column.
-func (r *Repo) GetProjectColumn(projectColumnID int) (*github.ProjectColumn, error) {
-	return r.cli.GetProjectColumn(projectColumnID)
+func (r *Repo) GetProjectColumn(ctx context.Context, projectColumnID int) (*github.ProjectColumn, error) {
+	return r.cli.GetProjectColumn(ctx, projectColumnID)
 }
 
 // CreateProjectColumn creates a project column.
-func (r *Repo) CreateProjectColumn(projectID int, name string) (*github.ProjectColumn, error) {
-	return r.cli.CreateProjectColumn(projectID, name)
+func (r *Repo) CreateProjectColumn(ctx context.Context, projectID int, name string) (*github.ProjectColumn, error) {
+	return r.cli.CreateProjectColumn(ctx, projectID, name)
 }
 
 // UpdateProjectColumn updates the project column..
-func (r *Repo) UpdateProjectColumn(projectColumnID int, name string) (*github.ProjectColumn, error) {
-	return r.cli.UpdateProjectColumn(projectColumnID, name)
+func (r *Repo) UpdateProjectColumn(ctx context.Context, projectColumnID int, name string) (*github.ProjectColumn, error) {
+	return r.cli.UpdateProjectColumn(ctx, projectColumnID, name)
 }
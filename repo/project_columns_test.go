@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListProjectColumns(t *testing.T) {
 			return github.ProjectColumnsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.ProjectColumnsToSlice(repo.ListProjectColumns(1))
+	got, err := github.ProjectColumnsToSlice(repo.ListProjectColumns(context.Background(), 1))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -39,7 +40,7 @@ func TestRepoGetProjectColumn(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetProjectColumn(1)
+	got, err := repo.GetProjectColumn(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -54,7 +55,7 @@ func TestRepoCreateProjectColumn(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateProjectColumn(10, "Test project column 1")
+	got, err := repo.CreateProjectColumn(context.Background(), 10, "Test project column 1")
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -69,7 +70,7 @@ func TestRepoUpdateProjectColumn(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateProjectColumn(1, "Test project column 1")
+	got, err := repo.UpdateProjectColumn(context.Background(), 1, "Test project column 1")
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -1,30 +1,34 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListProjects lists the projects.
-func (r *Repo) ListProjects() github.Projects {
-	return r.cli.ListProjects(r.path, &github.ListProjectsParams{
+func (r *Repo) ListProjects(ctx context.Context) github.Projects {
+	return r.cli.ListProjects(ctx, r.path, &github.ListProjectsParams{
 		State: github.ListProjectsParamStateAll,
 	})
 }
 
 // GetProject gets the project.
-func (r *Repo) GetProject(projectID int) (*github.Project, error) {
-	return r.cli.GetProject(projectID)
+func (r *Repo) GetProject(ctx context.Context, projectID int) (*github.Project, error) {
+	return r.cli.GetProject(ctx, projectID)
 }
 
 // CreateProject creates a project.
-func (r *Repo) CreateProject(params *github.CreateProjectParams) (*github.Project, error) {
-	return r.cli.CreateProject(r.path, params)
+func (r *Repo) CreateProject(ctx context.Context, params *github.CreateProjectParams) (*github.Project, error) {
+	return r.cli.CreateProject(ctx, r.path, params)
 }
 
 // UpdateProject updates the project.
-func (r *Repo) UpdateProject(projectID int, params *github.UpdateProjectParams) (*github.Project, error) {
-	return r.cli.UpdateProject(projectID, params)
+func (r *Repo) UpdateProject(ctx context.Context, projectID int, params *github.UpdateProjectParams) (*github.Project, error) {
+	return r.cli.UpdateProject(ctx, projectID, params)
 }
 
 // DeleteProject deletes the project.
-func (r *Repo) DeleteProject(projectID int) error {
-	return r.cli.DeleteProject(projectID)
+func (r *Repo) DeleteProject(ctx context.Context, projectID int) error {
+	return r.cli.DeleteProject(ctx, projectID)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRepoListProjects(t *testing.T) {
 			return github.ProjectsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.ProjectsToSlice(repo.ListProjects())
+	got, err := github.ProjectsToSlice(repo.ListProjects(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -39,7 +40,7 @@ func TestRepoGetProject(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetProject(1)
+	got, err := repo.GetProject(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -56,7 +57,7 @@ func TestRepoCreateProject(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.CreateProject(&github.CreateProjectParams{
+	got, err := repo.CreateProject(context.Background(), &github.CreateProjectParams{
 		Name: "Test project 1",
 		Body: "Test body",
 	})
@@ -76,7 +77,7 @@ func TestRepoUpdateProject(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.UpdateProject(1, &github.UpdateProjectParams{
+	got, err := repo.UpdateProject(context.Background(), 1, &github.UpdateProjectParams{
 		Name:  "Test project 1",
 		Body:  "Test body",
 		State: github.ProjectStateClosed,
@@ -91,6 +92,6 @@ func TestRepoDeleteProject(t *testing.T) {
 			return nil
 		}),
 	), "example/test")
-	err := repo.DeleteProject(1)
+	err := repo.DeleteProject(context.Background(), 1)
 	assert.Nil(t, err)
 }
@@ -1,16 +1,20 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListPullReqs lists the pull requests.
-func (r *Repo) ListPullReqs() github.PullReqs {
-	return r.cli.ListPullReqs(r.path, &github.ListPullReqsParams{
+func (r *Repo) ListPullReqs(ctx context.Context) github.PullReqs {
+	return r.cli.ListPullReqs(ctx, r.path, &github.ListPullReqsParams{
 		State:     github.ListPullReqsParamStateAll,
 		Direction: github.ListPullReqsParamDirectionAsc,
 	})
 }
 
 // GetPullReq gets the pull request.
-func (r *Repo) GetPullReq(pullNumber int) (*github.PullReq, error) {
-	return r.cli.GetPullReq(r.path, pullNumber)
+func (r *Repo) GetPullReq(ctx context.Context, pullNumber int) (*github.PullReq, error) {
+	return r.cli.GetPullReq(ctx, r.path, pullNumber)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func TestRepoListPullReqs(t *testing.T) {
 			return github.PullReqsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.PullReqsToSlice(repo.ListPullReqs())
+	got, err := github.PullReqsToSlice(repo.ListPullReqs(context.Background()))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -63,7 +64,7 @@ func TestRepoGetPullReq(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetPullReq(1)
+	got, err := repo.GetPullReq(context.Background(), 1)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -1,8 +1,12 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListReviewComments lists the review comments.
-func (r *Repo) ListReviewComments(pullNumber int) github.ReviewComments {
-	return r.cli.ListReviewComments(r.path, pullNumber)
+func (r *Repo) ListReviewComments(ctx context.Context, pullNumber int) github.ReviewComments {
+	return r.cli.ListReviewComments(ctx, r.path, pullNumber)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ func TestRepoListReviewComments(t *testing.T) {
 			return github.ReviewCommentsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.ReviewCommentsToSlice(repo.ListReviewComments(1))
+	got, err := github.ReviewCommentsToSlice(repo.ListReviewComments(context.Background(), 1))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
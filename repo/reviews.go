@@ -1,13 +1,17 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListReviews lists the reviews.
-func (r *Repo) ListReviews(pullNumber int) github.Reviews {
-	return r.cli.ListReviews(r.path, pullNumber)
+func (r *Repo) ListReviews(ctx context.Context, pullNumber int) github.Reviews {
+	return r.cli.ListReviews(ctx, r.path, pullNumber)
 }
 
 // GetReview lists the reviews.
-func (r *Repo) GetReview(pullNumber, reviewID int) (*github.Review, error) {
-	return r.cli.GetReview(r.path, pullNumber, reviewID)
+func (r *Repo) GetReview(ctx context.Context, pullNumber, reviewID int) (*github.Review, error) {
+	return r.cli.GetReview(ctx, r.path, pullNumber, reviewID)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,7 +28,7 @@ func TestRepoListReviews(t *testing.T) {
 			return github.ReviewsFromSlice(expected)
 		}),
 	), "example/test")
-	got, err := github.ReviewsToSlice(repo.ListReviews(1))
+	got, err := github.ReviewsToSlice(repo.ListReviews(context.Background(), 1))
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
@@ -43,7 +44,7 @@ func TestRepoGetReview(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.GetReview(1, 2)
+	got, err := repo.GetReview(context.Background(), 1, 2)
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
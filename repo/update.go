@@ -1,8 +1,12 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // Update the repository.
-func (r *Repo) Update(params *github.UpdateRepoParams) (*github.Repo, error) {
-	return r.cli.UpdateRepo(r.path, params)
+func (r *Repo) Update(ctx context.Context, params *github.UpdateRepoParams) (*github.Repo, error) {
+	return r.cli.UpdateRepo(ctx, r.path, params)
 }
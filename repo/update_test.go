@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,7 +37,7 @@ func TestRepoUpdate(t *testing.T) {
 			return expected, nil
 		}),
 	), "example/test")
-	got, err := repo.Update(&github.UpdateRepoParams{
+	got, err := repo.Update(context.Background(), &github.UpdateRepoParams{
 		Name:        "test",
 		Description: "New description",
 		Homepage:    "http://localhost/new",
@@ -1,8 +1,12 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"context"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // GetUser gets a user.
-func (r *Repo) GetUser(name string) (*github.User, error) {
-	return r.cli.GetUser(name)
+func (r *Repo) GetUser(ctx context.Context, name string) (*github.User, error) {
+	return r.cli.GetUser(ctx, name)
 }